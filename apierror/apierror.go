@@ -0,0 +1,204 @@
+// Package apierror คือ error ที่ใช้ร่วมกันทุก endpoint และรูปแบบ RFC 7807 problem+json
+package apierror
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/windeesel365/assessment-tax/i18n"
+	"github.com/windeesel365/assessment-tax/jsonvalidate"
+)
+
+// Code คือรหัส error ที่คงที่ ให้ client แยกกรณีได้โดยไม่ต้องอ่าน detail ซึ่งเปลี่ยนตามภาษา
+type Code string
+
+const (
+	CodeBadRequest       Code = "bad_request"
+	CodeEmptyBody        Code = "empty_body"
+	CodeInvalidBody      Code = "invalid_body"
+	CodeInvalidJSON      Code = "invalid_json"
+	CodeInvalidField     Code = "invalid_field"
+	CodeInvalidKeys      Code = "invalid_keys"
+	CodeInvalidValue     Code = "invalid_value"
+	CodeInvalidParameter Code = "invalid_parameter"
+	CodeInvalidFile      Code = "invalid_file"
+	CodeUnauthorized     Code = "unauthorized"
+	CodeForbidden        Code = "forbidden"
+	CodeNotFound         Code = "not_found"
+	CodeMethodNotAllowed Code = "method_not_allowed"
+	CodeTooLarge         Code = "payload_too_large"
+	CodeUnsupportedMedia Code = "unsupported_media_type"
+	CodeTooManyRequests  Code = "too_many_requests"
+	CodeInternal         Code = "internal_error"
+	CodeUnavailable      Code = "service_unavailable"
+)
+
+// titles คือ title ของแต่ละ Code ซึ่งไม่เปลี่ยนตาม request นอกจากภาษา
+var titles = map[Code]string{
+	CodeBadRequest:       "Bad request",
+	CodeEmptyBody:        "Request body is empty",
+	CodeInvalidBody:      "Request body could not be read",
+	CodeInvalidJSON:      "Request body is not valid JSON",
+	CodeInvalidField:     "Request body has invalid fields",
+	CodeInvalidKeys:      "Request body has invalid keys",
+	CodeInvalidValue:     "Request value is invalid",
+	CodeInvalidParameter: "Request parameter is invalid",
+	CodeInvalidFile:      "Uploaded file is invalid",
+	CodeUnauthorized:     "Unauthorized",
+	CodeForbidden:        "Forbidden",
+	CodeNotFound:         "Not found",
+	CodeMethodNotAllowed: "Method not allowed",
+	CodeTooLarge:         "Payload too large",
+	CodeUnsupportedMedia: "Unsupported media type",
+	CodeTooManyRequests:  "Too many requests",
+	CodeInternal:         "Internal server error",
+	CodeUnavailable:      "Service unavailable",
+}
+
+// statusCodes คือ Code ของ HTTP status ที่ไม่ได้มาจาก Error เช่น *echo.HTTPError
+var statusCodes = map[int]Code{
+	http.StatusBadRequest:            CodeBadRequest,
+	http.StatusUnauthorized:          CodeUnauthorized,
+	http.StatusForbidden:             CodeForbidden,
+	http.StatusNotFound:              CodeNotFound,
+	http.StatusMethodNotAllowed:      CodeMethodNotAllowed,
+	http.StatusRequestEntityTooLarge: CodeTooLarge,
+	http.StatusUnsupportedMediaType:  CodeUnsupportedMedia,
+	http.StatusTooManyRequests:       CodeTooManyRequests,
+	http.StatusInternalServerError:   CodeInternal,
+	http.StatusServiceUnavailable:    CodeUnavailable,
+}
+
+// Title คืน title ของ code, code ที่ไม่รู้จักใช้ title ของ CodeBadRequest
+func (c Code) Title() string {
+	if title, ok := titles[c]; ok {
+		return title
+	}
+	return titles[CodeBadRequest]
+}
+
+// StatusCode คืน Code ของ HTTP status, status อื่นที่เป็น 5xx คือ CodeInternal และ 4xx คือ CodeBadRequest
+func StatusCode(status int) Code {
+	if code, ok := statusCodes[status]; ok {
+		return code
+	}
+	if status >= http.StatusInternalServerError {
+		return CodeInternal
+	}
+	return CodeBadRequest
+}
+
+// Error คือ error ที่ใช้ร่วมกันทุก endpoint ซึ่ง HTTP error handler แปลงเป็น Problem
+// Field คือ path ของ field ที่ผิด เช่น allowances[2].amount, Errors คือปัญหาทุกจุดจาก jsonvalidate.StrictDecode
+// Detail แปลตามภาษาของ request ตอนแปลงเป็น Problem, Err คือสาเหตุภายในสำหรับ log ซึ่งไม่ส่งให้ client
+type Error struct {
+	Status int
+	Code   Code
+	Detail i18n.Message
+	Field  string
+	Errors []jsonvalidate.FieldError
+	Err    error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Detail.String() + ": " + e.Err.Error()
+	}
+	return e.Detail.String()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// New สร้าง Error ของ status และ code, detail คือข้อความต้นฉบับที่ไม่มี format verb
+func New(status int, code Code, detail string) *Error {
+	return &Error{Status: status, Code: code, Detail: i18n.Text(detail)}
+}
+
+// Newf สร้าง Error ที่ detail เป็น format แบบ fmt.Sprintf, error ใน args แปลตามภาษาด้วย
+func Newf(status int, code Code, format string, args ...interface{}) *Error {
+	return &Error{Status: status, Code: code, Detail: i18n.Msg(format, args...)}
+}
+
+// BadRequest สร้าง Error status 400
+func BadRequest(code Code, detail string) *Error {
+	return New(http.StatusBadRequest, code, detail)
+}
+
+// BadRequestf สร้าง Error status 400 แบบ Newf เช่น BadRequestf(code, "%v", err)
+func BadRequestf(code Code, format string, args ...interface{}) *Error {
+	return Newf(http.StatusBadRequest, code, format, args...)
+}
+
+// Invalidf สร้าง Error CodeInvalidValue ของ field, detail คือข้อความเดิมของ validation
+func Invalidf(field, format string, args ...interface{}) *Error {
+	e := BadRequestf(CodeInvalidValue, format, args...)
+	e.Field = field
+	return e
+}
+
+// Internal สร้าง Error status 500 ที่แสดง detail ให้ client และเก็บ err ไว้สำหรับ log
+func Internal(detail string, err error) *Error {
+	e := New(http.StatusInternalServerError, CodeInternal, detail)
+	e.Err = err
+	return e
+}
+
+// Decode แปลง error จาก jsonvalidate.StrictDecode เป็น Error พร้อม path ของทุกปัญหา
+// body ที่ไม่ใช่ JSON คือ CodeInvalidJSON, ค่าของ field ผิดคือ CodeInvalidField
+func Decode(err error) *Error {
+	e := BadRequestf(CodeInvalidJSON, "Invalid input format: %v", err)
+
+	var fieldErrs jsonvalidate.DecodeErrors
+	if !errors.As(err, &fieldErrs) {
+		return e
+	}
+	e.Errors = fieldErrs
+	for _, fieldErr := range fieldErrs {
+		if fieldErr.Syntax() || fieldErr.Path == "" {
+			return e
+		}
+	}
+	e.Code = CodeInvalidField
+	e.Field = fieldErrs[0].Path
+	return e
+}
+
+// Validation แปลง error จากการ validate หรือคำนวน เป็น Error status 400
+// *Error คงไว้ตามเดิม, error อื่นเป็น CodeInvalidValue ที่ไม่ระบุ field
+func Validation(err error) *Error {
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+	return BadRequestf(CodeInvalidValue, "%v", err)
+}
+
+// Prefix เติม path ของ object ที่ครอบอยู่ หน้า detail และ field ของ err
+// เช่น scenarios[1].request ของ tax/compare
+func Prefix(err error, path string) *Error {
+	source := Validation(err)
+	e := *source
+	e.Detail = i18n.Msg("%s: %v", path, source.Detail)
+	e.Field = joinPath(path, source.Field)
+	if len(source.Errors) > 0 {
+		e.Errors = make([]jsonvalidate.FieldError, len(source.Errors))
+		for i, fieldErr := range source.Errors {
+			fieldErr.Path = joinPath(path, fieldErr.Path)
+			e.Errors[i] = fieldErr
+		}
+	}
+	return &e
+}
+
+func joinPath(path, field string) string {
+	if field == "" {
+		return path
+	}
+	if strings.HasPrefix(field, "[") {
+		return path + field
+	}
+	return path + "." + field
+}
@@ -0,0 +1,113 @@
+package apierror
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/windeesel365/assessment-tax/jsonvalidate"
+)
+
+type decodeTarget struct {
+	TotalIncome float64 `json:"totalIncome"`
+	Allowances  []struct {
+		Amount float64 `json:"amount"`
+	} `json:"allowances"`
+}
+
+func TestStatusCode(t *testing.T) {
+	tests := []struct {
+		status int
+		want   Code
+	}{
+		{http.StatusBadRequest, CodeBadRequest},
+		{http.StatusUnauthorized, CodeUnauthorized},
+		{http.StatusNotFound, CodeNotFound},
+		{http.StatusMethodNotAllowed, CodeMethodNotAllowed},
+		{http.StatusConflict, CodeBadRequest},
+		{http.StatusBadGateway, CodeInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.status), func(t *testing.T) {
+			if got := StatusCode(tt.status); got != tt.want {
+				t.Errorf("StatusCode(%d) = %q, want %q", tt.status, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		wantCode  Code
+		wantField string
+		wantPaths []string
+	}{
+		{"Field errors", `{"totalIncome":"x","allowances":[{"amount":1,"amount":2}]}`, CodeInvalidField, "totalIncome", []string{"totalIncome", "allowances[0].amount"}},
+		{"Syntax error", `{"totalIncome":1,"allowances":[}`, CodeInvalidJSON, "", []string{"allowances"}},
+		{"Trailing data", `{"totalIncome":1} {}`, CodeInvalidJSON, "", []string{""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Decode(jsonvalidate.StrictDecode([]byte(tt.body), &decodeTarget{}))
+			if err.Status != http.StatusBadRequest || err.Code != tt.wantCode || err.Field != tt.wantField {
+				t.Errorf("Decode() = %d %q field %q, want 400 %q field %q", err.Status, err.Code, err.Field, tt.wantCode, tt.wantField)
+			}
+			var paths []string
+			for _, fieldErr := range err.Errors {
+				paths = append(paths, fieldErr.Path)
+			}
+			if !reflect.DeepEqual(paths, tt.wantPaths) {
+				t.Errorf("Decode() paths = %v, want %v", paths, tt.wantPaths)
+			}
+		})
+	}
+}
+
+func TestValidation(t *testing.T) {
+	plain := Validation(errors.New("at least one allowance must be provided"))
+	if plain.Status != http.StatusBadRequest || plain.Code != CodeInvalidValue || plain.Field != "" {
+		t.Errorf("Validation(plain) = %+v", plain)
+	}
+
+	field := Invalidf("wht", "wht must be a non-negative value")
+	if got := Validation(field); got != field {
+		t.Errorf("Validation(*Error) = %+v, want the same error", got)
+	}
+}
+
+func TestPrefix(t *testing.T) {
+	source := Decode(jsonvalidate.StrictDecode([]byte(`{"totalIncome":"x"}`), &decodeTarget{}))
+	got := Prefix(source, "scenarios[1].request")
+
+	if got.Field != "scenarios[1].request.totalIncome" || got.Errors[0].Path != "scenarios[1].request.totalIncome" {
+		t.Errorf("Prefix() field = %q, errors = %v", got.Field, got.Errors)
+	}
+	if got.Detail.String() != "scenarios[1].request: "+source.Detail.String() {
+		t.Errorf("Prefix() detail = %q", got.Detail)
+	}
+	if source.Field != "totalIncome" || source.Errors[0].Path != "totalIncome" {
+		t.Errorf("Prefix() modified source error: %+v", source)
+	}
+
+	plain := Prefix(errors.New("wht must be a non-negative value"), "scenarios[0].request")
+	if plain.Field != "scenarios[0].request" || plain.Code != CodeInvalidValue {
+		t.Errorf("Prefix(plain) = %+v", plain)
+	}
+}
+
+func TestInternal(t *testing.T) {
+	cause := errors.New("connection refused")
+	err := Internal("Could not save deduction, please try again", cause)
+
+	if !errors.Is(err, cause) {
+		t.Errorf("Internal() does not wrap cause")
+	}
+	if err.Status != http.StatusInternalServerError || err.Code != CodeInternal {
+		t.Errorf("Internal() = %d %q", err.Status, err.Code)
+	}
+}
@@ -0,0 +1,40 @@
+package apierror
+
+import "github.com/windeesel365/assessment-tax/i18n"
+
+// คำแปลภาษาไทยของ title, detail ที่ใช้ร่วมกันทุก endpoint และข้อความของ *echo.HTTPError ซึ่งคือ http.StatusText
+func init() {
+	i18n.Register(i18n.Thai, map[string]string{
+		// title ของแต่ละ Code
+		"Bad request":                     "คำขอไม่ถูกต้อง",
+		"Request body is empty":           "ไม่มีข้อมูลใน request body",
+		"Request body could not be read":  "request body อ่านไม่ได้",
+		"Request body is not valid JSON":  "request body ไม่ใช่ JSON ที่ถูกต้อง",
+		"Request body has invalid fields": "request body มี field ที่ไม่ถูกต้อง",
+		"Request body has invalid keys":   "request body มี key ที่ไม่ถูกต้อง",
+		"Request value is invalid":        "ค่าที่ส่งมาไม่ถูกต้อง",
+		"Request parameter is invalid":    "parameter ของคำขอไม่ถูกต้อง",
+		"Uploaded file is invalid":        "ไฟล์ที่อัปโหลดไม่ถูกต้อง",
+		"Unauthorized":                    "ไม่ได้รับอนุญาต",
+		"Forbidden":                       "ไม่มีสิทธิ์เข้าถึง",
+		"Not found":                       "ไม่พบข้อมูล",
+		"Method not allowed":              "ไม่รองรับ method นี้",
+		"Payload too large":               "ข้อมูลมีขนาดใหญ่เกินไป",
+		"Unsupported media type":          "ไม่รองรับ media type นี้",
+		"Too many requests":               "มีคำขอมากเกินไป",
+		"Internal server error":           "เกิดข้อผิดพลาดภายในระบบ",
+		"Service unavailable":             "ระบบไม่พร้อมให้บริการ",
+
+		// detail
+		"Invalid input":            "ข้อมูลไม่ถูกต้อง",
+		"Invalid input format: %v": "รูปแบบข้อมูลไม่ถูกต้อง: %v",
+		"Bad Request":              "คำขอไม่ถูกต้อง",
+		"Not Found":                "ไม่พบข้อมูล",
+		"Method Not Allowed":       "ไม่รองรับ method นี้",
+		"Request Entity Too Large": "ข้อมูลมีขนาดใหญ่เกินไป",
+		"Unsupported Media Type":   "ไม่รองรับ media type นี้",
+		"Too Many Requests":        "มีคำขอมากเกินไป",
+		"Internal Server Error":    "เกิดข้อผิดพลาดภายในระบบ",
+		"Service Unavailable":      "ระบบไม่พร้อมให้บริการ",
+	})
+}
@@ -0,0 +1,67 @@
+package apierror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/windeesel365/assessment-tax/i18n"
+	"github.com/windeesel365/assessment-tax/jsonvalidate"
+)
+
+// ContentType คือ media type ของ Problem ตาม RFC 7807
+const ContentType = "application/problem+json"
+
+// typeURIPrefix นำหน้า Code เป็น type ของ Problem
+const typeURIPrefix = "urn:assessment-tax:problem:"
+
+// Problem คือ response body ของ error ทุก endpoint ตาม RFC 7807
+// type/title/status/detail/instance เป็น member มาตรฐาน, code/field/errors/requestId เป็น extension
+type Problem struct {
+	Type      string                    `json:"type"`
+	Title     string                    `json:"title"`
+	Status    int                       `json:"status"`
+	Detail    string                    `json:"detail,omitempty"`
+	Instance  string                    `json:"instance,omitempty"`
+	Code      Code                      `json:"code"`
+	Field     string                    `json:"field,omitempty"`
+	Errors    []jsonvalidate.FieldError `json:"errors,omitempty"`
+	RequestID string                    `json:"requestId,omitempty"`
+}
+
+// NewProblem แปลง error ที่ handler คืนมาเป็น Problem โดย title, detail และ message ของ errors แสดงเป็นภาษา lang
+// *Error ใช้ตามที่ระบุ, *echo.HTTPError ใช้ status และ message เดิม
+// error อื่นคือ programming error จึงเป็น 500 โดยไม่ส่งข้อความภายในให้ client
+func NewProblem(err error, instance, requestID string, lang i18n.Lang) Problem {
+	var e *Error
+	var httpErr *echo.HTTPError
+	switch {
+	case errors.As(err, &e):
+	case errors.As(err, &httpErr):
+		e = New(httpErr.Code, StatusCode(httpErr.Code), fmt.Sprint(httpErr.Message))
+	default:
+		e = Internal(http.StatusText(http.StatusInternalServerError), err)
+	}
+
+	var fieldErrs []jsonvalidate.FieldError
+	if len(e.Errors) > 0 {
+		fieldErrs = make([]jsonvalidate.FieldError, len(e.Errors))
+		for i, fieldErr := range e.Errors {
+			fieldErr.Message = fieldErr.Localize(lang)
+			fieldErrs[i] = fieldErr
+		}
+	}
+
+	return Problem{
+		Type:      typeURIPrefix + string(e.Code),
+		Title:     i18n.Text(e.Code.Title()).In(lang),
+		Status:    e.Status,
+		Detail:    e.Detail.In(lang),
+		Instance:  instance,
+		Code:      e.Code,
+		Field:     e.Field,
+		Errors:    fieldErrs,
+		RequestID: requestID,
+	}
+}
@@ -0,0 +1,136 @@
+package apierror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/windeesel365/assessment-tax/i18n"
+	"github.com/windeesel365/assessment-tax/jsonvalidate"
+)
+
+func TestNewProblem(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   Code
+		wantDetail string
+		wantField  string
+	}{
+		{
+			name:       "Field error",
+			err:        Invalidf("incomes[0].amount", "incomes[0].amount must be a non-negative value"),
+			wantStatus: http.StatusBadRequest,
+			wantCode:   CodeInvalidValue,
+			wantDetail: "incomes[0].amount must be a non-negative value",
+			wantField:  "incomes[0].amount",
+		},
+		{
+			name:       "Wrapped error",
+			err:        fmt.Errorf("calculate: %w", BadRequest(CodeInvalidKeys, "unknown key name: year. Then process again")),
+			wantStatus: http.StatusBadRequest,
+			wantCode:   CodeInvalidKeys,
+			wantDetail: "unknown key name: year. Then process again",
+		},
+		{
+			name:       "Echo HTTP error",
+			err:        echo.ErrNotFound,
+			wantStatus: http.StatusNotFound,
+			wantCode:   CodeNotFound,
+			wantDetail: "Not Found",
+		},
+		{
+			name:       "Internal error hides cause",
+			err:        Internal("Could not save deduction, please try again", errors.New("pq: connection refused")),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   CodeInternal,
+			wantDetail: "Could not save deduction, please try again",
+		},
+		{
+			name:       "Unexpected error",
+			err:        errors.New("pq: connection refused"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   CodeInternal,
+			wantDetail: "Internal Server Error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewProblem(tt.err, "/tax/calculations", "req-1", "")
+			if got.Status != tt.wantStatus || got.Code != tt.wantCode || got.Detail != tt.wantDetail || got.Field != tt.wantField {
+				t.Errorf("NewProblem() = %+v", got)
+			}
+			if got.Type != typeURIPrefix+string(tt.wantCode) || got.Title != tt.wantCode.Title() {
+				t.Errorf("NewProblem() type = %q, title = %q", got.Type, got.Title)
+			}
+			if got.Instance != "/tax/calculations" || got.RequestID != "req-1" {
+				t.Errorf("NewProblem() instance = %q, requestId = %q", got.Instance, got.RequestID)
+			}
+		})
+	}
+}
+
+func TestNewProblemLang(t *testing.T) {
+	decodeErr := Decode(jsonvalidate.DecodeErrors{{Path: "totalIncome", Message: "must be a number"}})
+
+	tests := []struct {
+		name        string
+		err         error
+		lang        i18n.Lang
+		wantTitle   string
+		wantDetail  string
+		wantMessage string
+	}{
+		{
+			name:        "Source text",
+			err:         decodeErr,
+			wantTitle:   "Request body has invalid fields",
+			wantDetail:  "Invalid input format: totalIncome: must be a number",
+			wantMessage: "must be a number",
+		},
+		{
+			name:        "Thai",
+			err:         decodeErr,
+			lang:        i18n.Thai,
+			wantTitle:   "request body มี field ที่ไม่ถูกต้อง",
+			wantDetail:  "รูปแบบข้อมูลไม่ถูกต้อง: totalIncome: ต้องเป็นตัวเลข",
+			wantMessage: "ต้องเป็นตัวเลข",
+		},
+		{
+			name:       "Thai prefixed error",
+			err:        Prefix(BadRequest(CodeInvalidBody, "Invalid input"), "scenarios[0].request"),
+			lang:       i18n.Thai,
+			wantTitle:  "request body อ่านไม่ได้",
+			wantDetail: "scenarios[0].request: ข้อมูลไม่ถูกต้อง",
+		},
+		{
+			name:       "Thai echo error",
+			err:        echo.ErrNotFound,
+			lang:       i18n.Thai,
+			wantTitle:  "ไม่พบข้อมูล",
+			wantDetail: "ไม่พบข้อมูล",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewProblem(tt.err, "/tax/calculations", "", tt.lang)
+			if got.Title != tt.wantTitle || got.Detail != tt.wantDetail {
+				t.Errorf("NewProblem() title = %q, detail = %q; want %q, %q", got.Title, got.Detail, tt.wantTitle, tt.wantDetail)
+			}
+			if tt.wantMessage != "" && (len(got.Errors) != 1 || got.Errors[0].Message != tt.wantMessage) {
+				t.Errorf("NewProblem() errors = %+v, want message %q", got.Errors, tt.wantMessage)
+			}
+		})
+	}
+
+	// message ของ Error ต้นทางไม่ถูกแก้ไข
+	var source *Error
+	if errors.As(decodeErr, &source) && source.Errors[0].Message != "must be a number" {
+		t.Errorf("source message = %q", source.Errors[0].Message)
+	}
+}
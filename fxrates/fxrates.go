@@ -0,0 +1,118 @@
+// Package fxrates เก็บตารางอัตราแลกเปลี่ยนที่ admin กำหนด ไว้ใน memory
+// สำหรับแปลงเงินได้สกุลต่างประเทศเป็นบาทก่อนคำนวนภาษี
+package fxrates
+
+import (
+	"encoding/json"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/windeesel365/assessment-tax/i18n"
+)
+
+// BaseCurrency คือสกุลเงินที่ใช้คำนวนภาษี
+const BaseCurrency = "THB"
+
+// DateLayout คือรูปแบบวันที่ของอัตราแลกเปลี่ยนรายวัน
+const DateLayout = "2006-01-02"
+
+var currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// Rate คืออัตราแลกเปลี่ยน 1 หน่วยของ Currency เป็นบาท
+// Date เป็น nil คืออัตราเฉลี่ยทั้งปีภาษี TaxYear, ไม่เป็น nil คืออัตราของวันนั้น
+type Rate struct {
+	Currency string
+	TaxYear  int
+	Date     *time.Time
+	Rate     decimal.Decimal
+}
+
+// MarshalJSON แสดง rate เป็นตัวเลข และ date เป็น "2006-01-02"
+func (r Rate) MarshalJSON() ([]byte, error) {
+	out := struct {
+		Currency string      `json:"currency"`
+		TaxYear  int         `json:"taxYear"`
+		Date     string      `json:"date,omitempty"`
+		Rate     json.Number `json:"rate"`
+	}{Currency: r.Currency, TaxYear: r.TaxYear, Rate: json.Number(r.Rate.String())}
+	if r.Date != nil {
+		out.Date = r.Date.Format(DateLayout)
+	}
+	return json.Marshal(out)
+}
+
+// sameKey เป็น true เมื่อเป็นอัตราของสกุลเงิน ปีภาษี และวันเดียวกัน
+func (r Rate) sameKey(other Rate) bool {
+	if r.Currency != other.Currency || r.TaxYear != other.TaxYear || (r.Date == nil) != (other.Date == nil) {
+		return false
+	}
+	return r.Date == nil || r.Date.Equal(*other.Date)
+}
+
+// ตารางอัตราแลกเปลี่ยน admin แก้ไขได้ระหว่างที่มี request คำนวนภาษี จึงต้องใช้ lock
+var (
+	mu    sync.RWMutex
+	rates []Rate
+)
+
+// ValidCurrency เช็คว่า currency เป็นรหัส ISO 4217 ตัวพิมพ์ใหญ่ 3 ตัว
+func ValidCurrency(currency string) bool {
+	return currencyPattern.MatchString(currency)
+}
+
+// Load แทนที่ตารางทั้งหมด เช่น ตอนโหลดจาก postgresql เมื่อ start server
+func Load(all []Rate) {
+	mu.Lock()
+	defer mu.Unlock()
+	rates = append([]Rate(nil), all...)
+}
+
+// Set เพิ่มหรือแก้ไขอัตราของสกุลเงิน ปีภาษี และวันเดียวกัน
+func Set(rate Rate) {
+	mu.Lock()
+	defer mu.Unlock()
+	for i := range rates {
+		if rates[i].sameKey(rate) {
+			rates[i] = rate
+			return
+		}
+	}
+	rates = append(rates, rate)
+}
+
+// All คืนสำเนาของตารางทั้งหมด
+func All() []Rate {
+	mu.RLock()
+	defer mu.RUnlock()
+	return append([]Rate{}, rates...)
+}
+
+// Lookup หาอัตราแลกเปลี่ยนของ currency: ใช้อัตราของวัน date ถ้ามี ไม่เช่นนั้นใช้อัตราเฉลี่ยของ taxYear
+// BaseCurrency มีอัตรา 1 เสมอ
+func Lookup(currency string, taxYear int, date *time.Time) (Rate, error) {
+	if currency == BaseCurrency {
+		return Rate{Currency: BaseCurrency, TaxYear: taxYear, Rate: decimal.NewFromInt(1)}, nil
+	}
+
+	mu.RLock()
+	defer mu.RUnlock()
+
+	var yearAverage *Rate
+	for i, rate := range rates {
+		if rate.Currency != currency || rate.TaxYear != taxYear {
+			continue
+		}
+		if rate.Date == nil {
+			yearAverage = &rates[i]
+		} else if date != nil && rate.Date.Equal(*date) {
+			return rate, nil
+		}
+	}
+	if yearAverage != nil {
+		return *yearAverage, nil
+	}
+
+	return Rate{}, i18n.Errorf("no exchange rate for %s in tax year %d", currency, taxYear)
+}
@@ -0,0 +1,92 @@
+package fxrates
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestLookup(t *testing.T) {
+	saved := All()
+	defer Load(saved)
+
+	day := time.Date(2024, time.June, 14, 0, 0, 0, 0, time.UTC)
+	otherDay := time.Date(2024, time.June, 15, 0, 0, 0, 0, time.UTC)
+	Load([]Rate{
+		{Currency: "USD", TaxYear: 2567, Rate: decimal.RequireFromString("35.5")},
+		{Currency: "USD", TaxYear: 2567, Date: &day, Rate: decimal.RequireFromString("36.75")},
+		{Currency: "JPY", TaxYear: 2566, Rate: decimal.RequireFromString("0.25")},
+	})
+
+	tests := []struct {
+		name     string
+		currency string
+		taxYear  int
+		date     *time.Time
+		want     string
+		wantErr  bool
+	}{
+		{"Base currency", "THB", 2567, nil, "1", false},
+		{"Year average", "USD", 2567, nil, "35.5", false},
+		{"Daily rate", "USD", 2567, &day, "36.75", false},
+		{"No daily rate falls back to year average", "USD", 2567, &otherDay, "35.5", false},
+		{"Other tax year", "JPY", 2567, nil, "", true},
+		{"Unknown currency", "EUR", 2567, nil, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Lookup(tt.currency, tt.taxYear, tt.date)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Lookup() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !got.Rate.Equal(decimal.RequireFromString(tt.want)) {
+				t.Errorf("Lookup() = %v, want %v", got.Rate, tt.want)
+			}
+		})
+	}
+}
+
+func TestSet(t *testing.T) {
+	saved := All()
+	defer Load(saved)
+
+	Load(nil)
+	Set(Rate{Currency: "EUR", TaxYear: 2567, Rate: decimal.RequireFromString("38")})
+	Set(Rate{Currency: "EUR", TaxYear: 2567, Rate: decimal.RequireFromString("39.1")})
+
+	all := All()
+	if len(all) != 1 || !all[0].Rate.Equal(decimal.RequireFromString("39.1")) {
+		t.Errorf("Set() did not replace existing rate, got %+v", all)
+	}
+}
+
+func TestValidCurrency(t *testing.T) {
+	tests := []struct {
+		currency string
+		want     bool
+	}{
+		{"USD", true},
+		{"usd", false},
+		{"US", false},
+		{"USDT", false},
+	}
+	for _, tt := range tests {
+		if got := ValidCurrency(tt.currency); got != tt.want {
+			t.Errorf("ValidCurrency(%q) = %v, want %v", tt.currency, got, tt.want)
+		}
+	}
+}
+
+func TestRateMarshalJSON(t *testing.T) {
+	day := time.Date(2024, time.June, 14, 0, 0, 0, 0, time.UTC)
+	got, err := json.Marshal(Rate{Currency: "USD", TaxYear: 2567, Date: &day, Rate: decimal.RequireFromString("36.75")})
+	if err != nil {
+		t.Fatalf("MarshalJSON() error = %v", err)
+	}
+	if want := `{"currency":"USD","taxYear":2567,"date":"2024-06-14","rate":36.75}`; string(got) != want {
+		t.Errorf("MarshalJSON() = %s, want %s", got, want)
+	}
+}
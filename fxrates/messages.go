@@ -0,0 +1,10 @@
+package fxrates
+
+import "github.com/windeesel365/assessment-tax/i18n"
+
+// คำแปลภาษาไทยของ error ใน package fxrates
+func init() {
+	i18n.Register(i18n.Thai, map[string]string{
+		"no exchange rate for %s in tax year %d": "ไม่มีอัตราแลกเปลี่ยนของ %s ในปีภาษี %d",
+	})
+}
@@ -2,53 +2,45 @@ package handlefileupload
 
 import (
 	"encoding/csv"
-	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
 
 	"github.com/labstack/echo/v4"
-	"github.com/shopspring/decimal"
-	"github.com/windeesel365/assessment-tax/sharedvars"
+	"github.com/windeesel365/assessment-tax/apierror"
+	"github.com/windeesel365/assessment-tax/money"
 	"github.com/windeesel365/assessment-tax/taxcal"
 )
 
-// CustomFloat64 เป็น float64 ที่ custom ใหม่
-type CustomFloat64 float64
-
-// customizes ตัวเลขการเงิน เพื่อ output decimal places ตามต้องการ
-func (cf CustomFloat64) MarshalJSON() ([]byte, error) {
-	d := decimal.NewFromFloat(float64(cf))     //จาก github.com/shopspring/decimal
-	formatted := d.RoundBank(1).StringFixed(1) // RoundBank เพื่อ banker's rounding // StringFixed ตำแหน่งทศนิยมในข้อมูลที่จะแสดงผล
-	return []byte(formatted), nil
-}
-
 type IncomewithTaxResponse struct {
-	Totalincome CustomFloat64 `json:"totalIncome"`
-	Tax         CustomFloat64 `json:"tax"`
-	TaxRefund   CustomFloat64 `json:"taxRefund,omitempty"`
+	Totalincome money.Money  `json:"totalIncome"`
+	Tax         money.Money  `json:"tax"`
+	TaxRefund   *money.Money `json:"taxRefund,omitempty"`
+	TaxYear     int          `json:"taxYear,omitempty"`
 }
 
 func HandleFileUpload(c echo.Context) error {
 	// Retrieve uploaded file จาก form-data
 	file, err := c.FormFile("taxFile") //Postman API test ที่ Key กรอก taxFile
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "Key: taxFile is required")
+		e := apierror.BadRequest(apierror.CodeInvalidFile, "Key: taxFile is required")
+		e.Field = "taxFile"
+		return e
 	}
 
 	//check format .csv  ไม่ใช่return error
 	if !strings.HasSuffix(file.Filename, ".csv") {
-		return echo.NewHTTPError(http.StatusBadRequest, "File must end with '.csv'")
+		return apierror.BadRequest(apierror.CodeInvalidFile, "File must end with '.csv'")
 	}
 
 	//check filename "taxes.csv"
 	if file.Filename != "taxes.csv" {
-		return echo.NewHTTPError(http.StatusBadRequest, "File must be named 'taxes.csv' Please rename the file correctly then upload again.")
+		return apierror.BadRequest(apierror.CodeInvalidFile, "File must be named 'taxes.csv' Please rename the file correctly then upload again.")
 	}
 
 	src, err := file.Open()
 	if err != nil {
-		return err
+		return apierror.Internal("Could not open uploaded file, please try again", err)
 	}
 	defer src.Close()
 
@@ -56,60 +48,83 @@ func HandleFileUpload(c echo.Context) error {
 	csvReader := csv.NewReader(src)
 	records, err := csvReader.ReadAll()
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "Failed to read CSV file")
+		return apierror.BadRequest(apierror.CodeInvalidFile, "Failed to read CSV file")
 	}
 
 	var results []IncomewithTaxResponse
 
 	expected := []string{"totalIncome", "wht", "donation"}
+	optionalTaxYear := "taxYear" // column ที่ 4 (optional) สำหรับระบุปีภาษีรายแถว
+	columns := len(expected)
 	for i, record := range records {
 		if i == 0 {
-
+			if len(record) < len(expected) {
+				return apierror.BadRequest(apierror.CodeInvalidFile, "Failed to read CSV file: header pattern not matched as expected")
+			}
 			for index, value := range expected {
 				if record[index] != value {
-					return echo.NewHTTPError(http.StatusBadRequest, "Failed to read CSV file: header pattern not matched as expected")
+					return apierror.BadRequest(apierror.CodeInvalidFile, "Failed to read CSV file: header pattern not matched as expected")
 				}
 			}
+			if len(record) > len(expected) {
+				if len(record) != len(expected)+1 || record[len(expected)] != optionalTaxYear {
+					return apierror.BadRequest(apierror.CodeInvalidFile, "Failed to read CSV file: header pattern not matched as expected")
+				}
+			}
+			columns = len(record)
 			continue // หลังจากvalidateก็skip header เลย เพราะไม่นำคำนวน
 		}
-		if len(record) != 3 {
-			return echo.NewHTTPError(http.StatusBadRequest, "Each row must contain exactly three entries")
+		if len(record) != columns {
+			return apierror.BadRequestf(apierror.CodeInvalidFile, "Each row must contain exactly %d entries", columns)
 		}
 
-		totalIncomeBefore, err := strconv.ParseFloat(strings.TrimSpace(record[0]), 64)
+		totalIncomeBefore, err := money.Parse(strings.TrimSpace(record[0]))
 		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid totalIncome number format. Please ensure input data (data row %d) of totalIncome column correctly,then process again.", i))
+			return apierror.BadRequestf(apierror.CodeInvalidFile, "Invalid totalIncome number format. Please ensure input data (data row %d) of totalIncome column correctly,then process again.", i)
 		}
 
-		wht, err := strconv.ParseFloat(strings.TrimSpace(record[1]), 64)
+		wht, err := money.Parse(strings.TrimSpace(record[1]))
 		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid wht number format. Please ensure input data (data row %d) of wht column correctly,then process again.", i))
+			return apierror.BadRequestf(apierror.CodeInvalidFile, "Invalid wht number format. Please ensure input data (data row %d) of wht column correctly,then process again.", i)
 		}
 
-		donations, err := strconv.ParseFloat(strings.TrimSpace(record[2]), 64)
+		donations, err := money.Parse(strings.TrimSpace(record[2]))
 		if err != nil {
-			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid donation number format. Please ensure input data (data row %d) of donation column correctly,then process again.", i))
+			return apierror.BadRequestf(apierror.CodeInvalidFile, "Invalid donation number format. Please ensure input data (data row %d) of donation column correctly,then process again.", i)
 		}
 
-		// csv ของ client ไม่มี personalExemption กับ kReceipts
-		// เราจึงใช้ค่าเริ่มต้น (ค่าเริ่มต้นของpersonalExemption admin ปรับได้ใน func setPersonalDeduction)
-		personalExemption := sharedvars.InitialPersonalExemption
-		kReceipts := sharedvars.InitialkReceipts
-
-		// หา taxable income
-		taxableIncome := taxcal.CaltaxableIncome(totalIncomeBefore, personalExemption, donations, kReceipts)
-
-		// หา taxPayable, taxRefund
-		taxPayable, taxRefund := taxcal.CalculateTaxPayableAndRefund(taxableIncome, wht)
-
-		// แสดงผลลัพธ์ตามรูปแบบ CustomFloat64(decimalทศนิยมแสดงdigitเดียว)
-		totalIncome := CustomFloat64(totalIncomeBefore)
+		// taxYear ว่างหรือไม่มี column ใช้ taxcal.DefaultTaxYear
+		taxYear := 0
+		if columns > len(expected) && strings.TrimSpace(record[len(expected)]) != "" {
+			taxYear, err = strconv.Atoi(strings.TrimSpace(record[len(expected)]))
+			if err != nil {
+				return apierror.BadRequestf(apierror.CodeInvalidFile, "Invalid taxYear format. Please ensure input data (data row %d) of taxYear column correctly,then process again.", i)
+			}
+		}
 
-		results = append(results, IncomewithTaxResponse{
-			Totalincome: totalIncome,
-			Tax:         CustomFloat64(taxPayable),
-			TaxRefund:   CustomFloat64(taxRefund),
+		// csv ของ client มีแค่ donation ส่วน personalExemption กับ kReceipts
+		// registry ใช้ค่าเริ่มต้น (ค่าเริ่มต้นของpersonalExemption admin ปรับได้ใน func setPersonalDeduction)
+		calculation, err := taxcal.Calculate(taxcal.TaxInput{
+			TaxYear:     taxYear,
+			TotalIncome: totalIncomeBefore,
+			WHT:         wht,
+			Allowances:  []taxcal.AllowanceClaim{{AllowanceType: "donation", Amount: donations}},
 		})
+		if err != nil {
+			return apierror.BadRequestf(apierror.CodeInvalidFile, "%v (data row %d)", err, i)
+		}
+		taxPayable, taxRefund := calculation.TaxPayable, calculation.TaxRefund
+
+		result := IncomewithTaxResponse{
+			Totalincome: totalIncomeBefore,
+			Tax:         taxPayable,
+			TaxYear:     taxYear,
+		}
+		// taxRefund แสดงเฉพาะเมื่อมีเงินคืน เหมือน omitempty ของ float64 เดิม
+		if taxRefund.IsPositive() {
+			result.TaxRefund = &taxRefund
+		}
+		results = append(results, result)
 
 	}
 
@@ -0,0 +1,21 @@
+package handlefileupload
+
+import "github.com/windeesel365/assessment-tax/i18n"
+
+// คำแปลภาษาไทยของ error ของการอัปโหลดไฟล์ csv
+func init() {
+	i18n.Register(i18n.Thai, map[string]string{
+		"Key: taxFile is required":  "ต้องส่งไฟล์ใน key: taxFile",
+		"File must end with '.csv'": "ไฟล์ต้องลงท้ายด้วย '.csv'",
+		"File must be named 'taxes.csv' Please rename the file correctly then upload again.": "ไฟล์ต้องชื่อ 'taxes.csv' กรุณาเปลี่ยนชื่อไฟล์แล้วอัปโหลดใหม่",
+		"Could not open uploaded file, please try again":                                     "เปิดไฟล์ที่อัปโหลดไม่ได้ กรุณาลองใหม่",
+		"Failed to read CSV file":                                                            "อ่านไฟล์ CSV ไม่ได้",
+		"Failed to read CSV file: header pattern not matched as expected":                    "อ่านไฟล์ CSV ไม่ได้: header ไม่ตรงตามรูปแบบที่กำหนด",
+		"Each row must contain exactly %d entries":                                           "แต่ละแถวต้องมีข้อมูล %d ช่องพอดี",
+		"Invalid totalIncome number format. Please ensure input data (data row %d) of totalIncome column correctly,then process again.": "รูปแบบตัวเลขของ totalIncome ไม่ถูกต้อง กรุณาตรวจสอบข้อมูล (แถวที่ %d) ในคอลัมน์ totalIncome แล้วส่งใหม่",
+		"Invalid wht number format. Please ensure input data (data row %d) of wht column correctly,then process again.":                 "รูปแบบตัวเลขของ wht ไม่ถูกต้อง กรุณาตรวจสอบข้อมูล (แถวที่ %d) ในคอลัมน์ wht แล้วส่งใหม่",
+		"Invalid donation number format. Please ensure input data (data row %d) of donation column correctly,then process again.":       "รูปแบบตัวเลขของ donation ไม่ถูกต้อง กรุณาตรวจสอบข้อมูล (แถวที่ %d) ในคอลัมน์ donation แล้วส่งใหม่",
+		"Invalid taxYear format. Please ensure input data (data row %d) of taxYear column correctly,then process again.":                "รูปแบบของ taxYear ไม่ถูกต้อง กรุณาตรวจสอบข้อมูล (แถวที่ %d) ในคอลัมน์ taxYear แล้วส่งใหม่",
+		"%v (data row %d)": "%v (แถวที่ %d)",
+	})
+}
@@ -0,0 +1,48 @@
+package handletax
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/windeesel365/assessment-tax/apierror"
+	"github.com/windeesel365/assessment-tax/money"
+	"github.com/windeesel365/assessment-tax/taxcal"
+)
+
+// TaxAdviceResponse คือสิทธิลดหย่อนที่ยังเหลือ และภาษีที่จะประหยัดได้ของแต่ละชนิด
+type TaxAdviceResponse struct {
+	Tax           money.Money              `json:"tax"`
+	TaxableIncome money.Money              `json:"taxableIncome"`
+	MarginalRate  json.Number              `json:"marginalRate"`
+	Allowances    []taxcal.AllowanceAdvice `json:"allowances"`
+}
+
+// POST: /tax/advice
+// รับ body แบบเดียวกับ tax/calculations
+func HandleTaxAdvice(c echo.Context) error {
+	// Read body to a variable
+	body, err := ioutil.ReadAll(c.Request().Body)
+	if err != nil {
+		return apierror.BadRequest(apierror.CodeInvalidBody, "Invalid input")
+	}
+	defer c.Request().Body.Close()
+
+	req, err := parseTaxRequest(body, keyOrder(c))
+	if err != nil {
+		return apierror.Validation(err)
+	}
+
+	advice, err := taxcal.AdviseAllowances(req.taxInput(language(c)))
+	if err != nil {
+		return apierror.Validation(err)
+	}
+
+	return c.JSON(http.StatusOK, TaxAdviceResponse{
+		Tax:           advice.Result.Tax,
+		TaxableIncome: advice.Result.TaxableIncome,
+		MarginalRate:  json.Number(advice.MarginalRate.String()),
+		Allowances:    advice.Allowances,
+	})
+}
@@ -0,0 +1,76 @@
+package handletax
+
+import (
+	"io/ioutil"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/windeesel365/assessment-tax/apierror"
+	"github.com/windeesel365/assessment-tax/jsonvalidate"
+	"github.com/windeesel365/assessment-tax/validityguard"
+)
+
+// BatchItemResponse คือผลของรายการหนึ่งใน batch: Result แบบเดียวกับ tax/calculations หรือ Error ของรายการนั้น
+type BatchItemResponse struct {
+	Index  int                    `json:"index"`
+	Result map[string]interface{} `json:"result,omitempty"`
+	Error  *apierror.Problem      `json:"error,omitempty"`
+}
+
+// BatchResponse คือผลของทุกรายการเรียงตามลำดับใน request
+type BatchResponse struct {
+	Succeeded int                 `json:"succeeded"`
+	Failed    int                 `json:"failed"`
+	Results   []BatchItemResponse `json:"results"`
+}
+
+// POST: /tax/calculations/batch
+// รับ array ของ body แบบเดียวกับ tax/calculations ไม่เกิน limit รายการ
+// รายการที่ผิดได้ error ของตัวเอง โดยรายการอื่นยังคำนวนตามปกติ
+func HandleTaxBatchCalculation(limit int) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		// Read body to a variable
+		body, err := ioutil.ReadAll(c.Request().Body)
+		if err != nil {
+			return apierror.BadRequest(apierror.CodeInvalidBody, "Invalid input")
+		}
+		defer c.Request().Body.Close()
+
+		// bind JSON array แบบ strict, แต่ละรายการยังเป็น raw JSON
+		req := validityguard.BatchRequest{}
+		if err := jsonvalidate.StrictDecode(body, &req); err != nil {
+			return apierror.Decode(err)
+		}
+
+		if err := validityguard.ValidateBatchRequest(req, limit); err != nil {
+			return apierror.Validation(err)
+		}
+
+		// validate และคำนวนแต่ละรายการแบบเดียวกับ tax/calculations
+		response := BatchResponse{Results: make([]BatchItemResponse, 0, len(req))}
+		for i, item := range req {
+			itemResponse := BatchItemResponse{Index: i}
+			result, err := calculateBatchItem(c, item)
+			if err != nil {
+				problem := apierror.NewProblem(apierror.Validation(err), c.Request().URL.Path, requestID(c), language(c))
+				itemResponse.Error = &problem
+				response.Failed++
+			} else {
+				itemResponse.Result = result
+				response.Succeeded++
+			}
+			response.Results = append(response.Results, itemResponse)
+		}
+
+		return c.JSON(http.StatusOK, response)
+	}
+}
+
+// calculateBatchItem validate และคำนวนรายการหนึ่งใน batch
+func calculateBatchItem(c echo.Context, item []byte) (map[string]interface{}, error) {
+	req, err := parseTaxRequest(item, keyOrder(c))
+	if err != nil {
+		return nil, err
+	}
+	return calculateTaxResponse(c, req)
+}
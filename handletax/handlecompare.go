@@ -0,0 +1,78 @@
+package handletax
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/windeesel365/assessment-tax/apierror"
+	"github.com/windeesel365/assessment-tax/jsonvalidate"
+	"github.com/windeesel365/assessment-tax/taxcal"
+	"github.com/windeesel365/assessment-tax/validityguard"
+)
+
+// ScenarioResponse คือผลการคำนวนของ scenario หนึ่งรายการ
+// Result มีรูปแบบเดียวกับ response ของ tax/calculations, Difference เป็น nil สำหรับ baseline
+type ScenarioResponse struct {
+	Name       string                 `json:"name"`
+	Result     map[string]interface{} `json:"result"`
+	Difference *taxcal.TaxResultDiff  `json:"difference,omitempty"`
+}
+
+// CompareResponse คือผลของทุก scenario และผลต่างเทียบกับ baseline
+type CompareResponse struct {
+	Baseline  string             `json:"baseline"`
+	Scenarios []ScenarioResponse `json:"scenarios"`
+}
+
+// POST: /tax/compare
+// เทียบภาษีหลาย scenario เช่น ซื้อ SSF กับไม่ซื้อ, scenario แรกคือ baseline
+func HandleTaxComparison(c echo.Context) error {
+	// Read body to a variable
+	body, err := ioutil.ReadAll(c.Request().Body)
+	if err != nil {
+		return apierror.BadRequest(apierror.CodeInvalidBody, "Invalid input")
+	}
+	defer c.Request().Body.Close()
+
+	// bind JSON to struct แบบ strict, ไม่รับ key ที่ไม่รู้จักหรือซ้ำ
+	req := validityguard.CompareRequest{}
+	if err := jsonvalidate.StrictDecode(body, &req); err != nil {
+		return apierror.Decode(err)
+	}
+
+	if err := validityguard.ValidateCompareRequest(req); err != nil {
+		return apierror.Validation(err)
+	}
+
+	// validate และคำนวนแต่ละ scenario แบบเดียวกับ tax/calculations
+	var baseline taxcal.TaxResult
+	response := CompareResponse{Baseline: req.Scenarios[0].Name}
+	for i, scenario := range req.Scenarios {
+		taxReq, err := parseTaxRequest(scenario.Request, keyOrder(c))
+		if err != nil {
+			return scenarioError(i, err)
+		}
+		result, err := taxcal.Calculate(taxReq.taxInput(language(c)))
+		if err != nil {
+			return scenarioError(i, err)
+		}
+
+		scenarioResponse := ScenarioResponse{Name: scenario.Name, Result: buildTaxResponse(taxReq, result)}
+		if i == 0 {
+			baseline = result
+		} else {
+			diff := taxcal.CompareTaxResults(baseline, result)
+			scenarioResponse.Difference = &diff
+		}
+		response.Scenarios = append(response.Scenarios, scenarioResponse)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// scenarioError เติม path ของ scenario หน้า detail และ field ของ error
+func scenarioError(index int, err error) error {
+	return apierror.Prefix(err, fmt.Sprintf("scenarios[%d].request", index))
+}
@@ -0,0 +1,46 @@
+package handletax
+
+import (
+	"io/ioutil"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/windeesel365/assessment-tax/apierror"
+	"github.com/windeesel365/assessment-tax/jsonvalidate"
+	"github.com/windeesel365/assessment-tax/taxcal"
+	"github.com/windeesel365/assessment-tax/validityguard"
+)
+
+// POST: /tax/payroll
+// ตารางภาษีหัก ณ ที่จ่ายเงินเดือนรายเดือน (ภ.ง.ด.1) ทั้ง 12 เดือน
+func HandlePayrollWithholding(c echo.Context) error {
+	// Read body to a variable
+	body, err := ioutil.ReadAll(c.Request().Body)
+	if err != nil {
+		return apierror.BadRequest(apierror.CodeInvalidBody, "Invalid input")
+	}
+	defer c.Request().Body.Close()
+
+	// bind JSON to struct แบบ strict, ไม่รับ key ที่ไม่รู้จักหรือซ้ำ
+	req := validityguard.PayrollRequest{}
+	if err := jsonvalidate.StrictDecode(body, &req); err != nil {
+		return apierror.Decode(err)
+	}
+
+	if err := validityguard.ValidatePayrollRequest(req); err != nil {
+		return apierror.Validation(err)
+	}
+
+	result, err := taxcal.CalculatePayrollWithholding(taxcal.PayrollInput{
+		TaxYear:    req.TaxYear,
+		Salaries:   req.Salaries,
+		Bonuses:    req.Bonuses,
+		Allowances: req.Allowances,
+		Dependants: req.Dependants,
+	})
+	if err != nil {
+		return apierror.Validation(err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
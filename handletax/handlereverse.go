@@ -0,0 +1,61 @@
+package handletax
+
+import (
+	"io/ioutil"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/windeesel365/assessment-tax/apierror"
+	"github.com/windeesel365/assessment-tax/jsonvalidate"
+	"github.com/windeesel365/assessment-tax/money"
+	"github.com/windeesel365/assessment-tax/taxcal"
+	"github.com/windeesel365/assessment-tax/validityguard"
+)
+
+// ReverseResponse คือเงินได้ที่ต้องมี เพื่อให้ถึงเป้าหมาย net หรือ tax
+type ReverseResponse struct {
+	Target         string            `json:"target"`
+	GrossIncome    money.Money       `json:"grossIncome"`
+	Tax            money.Money       `json:"tax"`
+	IncomeAfterTax money.Money       `json:"incomeAfterTax"`
+	TaxLevel       []taxcal.TaxLevel `json:"taxLevel"`
+}
+
+// POST: /tax/reverse
+func HandleReverseCalculation(c echo.Context) error {
+	// Read body to a variable
+	body, err := ioutil.ReadAll(c.Request().Body)
+	if err != nil {
+		return apierror.BadRequest(apierror.CodeInvalidBody, "Invalid input")
+	}
+	defer c.Request().Body.Close()
+
+	// bind JSON to struct แบบ strict, ไม่รับ key ที่ไม่รู้จักหรือซ้ำ
+	req := validityguard.ReverseRequest{}
+	if err := jsonvalidate.StrictDecode(body, &req); err != nil {
+		return apierror.Decode(err)
+	}
+
+	if err := validityguard.ValidateReverseRequest(req); err != nil {
+		return apierror.Validation(err)
+	}
+
+	template := taxcal.TaxInput{
+		TaxYear:    req.TaxYear,
+		Allowances: req.Allowances,
+		Dependants: req.Dependants,
+		Lang:       language(c),
+	}
+	result, err := taxcal.CalculateGrossIncomeFor(req.Target, req.Amount, template, req.Section)
+	if err != nil {
+		return apierror.Validation(err)
+	}
+
+	return c.JSON(http.StatusOK, ReverseResponse{
+		Target:         req.Target,
+		GrossIncome:    result.GrossIncome,
+		Tax:            result.Tax,
+		IncomeAfterTax: result.GrossIncome.Sub(result.Tax),
+		TaxLevel:       result.TaxLevels,
+	})
+}
@@ -0,0 +1,43 @@
+package handletax
+
+import (
+	"io/ioutil"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/windeesel365/assessment-tax/apierror"
+	"github.com/windeesel365/assessment-tax/jsonvalidate"
+	"github.com/windeesel365/assessment-tax/taxcal"
+	"github.com/windeesel365/assessment-tax/validityguard"
+)
+
+// POST: /tax/severance
+// ภาษีเงินได้ก้อนเดียวที่จ่ายเพราะออกจากงาน แยกจากเงินได้อื่น สำหรับ HR คำนวนตอนพนักงานออก
+func HandleSeveranceCalculation(c echo.Context) error {
+	// Read body to a variable
+	body, err := ioutil.ReadAll(c.Request().Body)
+	if err != nil {
+		return apierror.BadRequest(apierror.CodeInvalidBody, "Invalid input")
+	}
+	defer c.Request().Body.Close()
+
+	// bind JSON to struct แบบ strict, ไม่รับ key ที่ไม่รู้จักหรือซ้ำ
+	req := validityguard.SeveranceRequest{}
+	if err := jsonvalidate.StrictDecode(body, &req); err != nil {
+		return apierror.Decode(err)
+	}
+
+	if err := validityguard.ValidateSeveranceRequest(req); err != nil {
+		return apierror.Validation(err)
+	}
+
+	result, err := taxcal.CalculateSeverance(taxcal.Severance{
+		Amount:         req.Amount,
+		YearsOfService: req.YearsOfService,
+	}, req.TaxYear, language(c))
+	if err != nil {
+		return apierror.Validation(err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
@@ -5,184 +5,142 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
-	"regexp"
-	"strings"
 
 	"github.com/labstack/echo/v4"
-	"github.com/shopspring/decimal"
-	"github.com/windeesel365/assessment-tax/jsonvalidate"
-	"github.com/windeesel365/assessment-tax/sharedvars"
+	"github.com/windeesel365/assessment-tax/apierror"
+	"github.com/windeesel365/assessment-tax/i18n"
+	"github.com/windeesel365/assessment-tax/money"
 	"github.com/windeesel365/assessment-tax/taxcal"
-	"github.com/windeesel365/assessment-tax/validityguard"
 )
 
-// CustomFloat64 เป็น float64 ที่ custom ใหม่
-type CustomFloat64 float64
-
-// customizes ตัวเลขการเงิน เพื่อ output decimal places ตามต้องการ
-func (cf CustomFloat64) MarshalJSON() ([]byte, error) {
-	d := decimal.NewFromFloat(float64(cf))     //จาก github.com/shopspring/decimal
-	formatted := d.RoundBank(1).StringFixed(1) // RoundBank เพื่อ banker's rounding // StringFixed ตำแหน่งทศนิยมในข้อมูลที่จะแสดงผล
-	return []byte(formatted), nil
-}
-
-// data structure pattern ที่ user client request
-type TaxRequest struct {
-	TotalIncome float64 `json:"totalIncome"`
-	WHT         float64 `json:"wht"`
-	Allowances  []struct {
-		AllowanceType string  `json:"allowanceType"`
-		Amount        float64 `json:"amount"`
-	} `json:"allowances"`
+type TaxResponse struct {
+	Tax       money.Money `json:"tax"`
+	TaxRefund money.Money `json:"taxRefund,omitempty"`
 }
 
-type TaxResponse struct {
-	Tax       CustomFloat64 `json:"tax"`
-	TaxRefund CustomFloat64 `json:"taxRefund,omitempty"`
+// TaxMethodResponse แสดงวิธีคำนวนภาษีที่ใช้จริง และภาษีของแต่ละวิธี
+type TaxMethodResponse struct {
+	Method         string      `json:"method"`
+	ProgressiveTax money.Money `json:"progressiveTax"`
+	GrossIncomeTax money.Money `json:"grossIncomeTax"`
 }
 
 func HandleTaxCalculation(c echo.Context) error {
 	// Read body to a variable
 	body, err := ioutil.ReadAll(c.Request().Body)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "Invalid input")
+		return apierror.BadRequest(apierror.CodeInvalidBody, "Invalid input")
 	}
 	defer c.Request().Body.Close()
 
-	// split จาก '{' และ '}' เพื่อเอาmember จะได้เช็ค redundantได้
-	re := regexp.MustCompile(`[{}]`)
-	parts := re.Split(string(body), -1)
+	// validate และ bind body เป็น TaxRequest
+	req, err := parseTaxRequest(body, keyOrder(c))
+	if err != nil {
+		return apierror.Validation(err)
+	}
 
-	for _, part := range parts {
+	responseMap, err := calculateTaxResponse(c, req)
+	if err != nil {
+		return apierror.Validation(err)
+	}
 
-		//checkว่าถ้า strings.Count "allowanceType" อยู่ใน string มากกว่า 1 ครั้ง
-		if strings.Count(part, "allowanceType") > 1 {
-			return echo.NewHTTPError(http.StatusBadRequest, "Input data 'allowanceType' more than once, check and fill again")
-		}
+	return c.JSON(http.StatusOK, responseMap)
+}
 
-		//checkว่าถ้า strings.Count "amount" อยู่ใน string มากกว่า 1 ครั้ง
-		if strings.Count(part, "amount") > 1 {
-			return echo.NewHTTPError(http.StatusBadRequest, "Input data 'amount' more than once, check and fill again")
-		}
+// calculateTaxResponse คำนวนภาษีของ req แล้วประกอบ response ตาม query parameter ของ request
+// ใช้ร่วมกันระหว่าง tax/calculations และแต่ละรายการของ tax/calculations/batch
+func calculateTaxResponse(c echo.Context, req *TaxRequest) (map[string]interface{}, error) {
+	// คำนวนภาษีด้วย taxcal engine: ค่าใช้จ่ายตามประเภทเงินได้, allowance registry,
+	// ค่าลดหย่อนครอบครัว และขั้นบันใดภาษีของปีภาษีที่เลือก
+	// ?explain=true บันทึกขั้นตอนการคำนวนทั้งหมดตามลำดับ
+	input := req.taxInput(language(c))
+	if c.QueryParam("explain") == "true" {
+		input.Trace = &taxcal.Trace{}
 	}
 
-	// bind JSON to struct และ check error
-	req := new(TaxRequest)
-	if err := json.Unmarshal(body, &req); err != nil {
-		// Provide a more detailed error message if JSON is incorrect
-		return echo.NewHTTPError(http.StatusBadRequest, "Invalid input format: "+err.Error())
+	result, err := taxcal.Calculate(input)
+	if err != nil {
+		return nil, err
 	}
 
-	// expected key order ที่ถูกต้อง เพื่อใช้ validate JSON order
-	expectedKeys := []string{"totalIncome", "wht", "allowances"}
+	responseMap := buildTaxResponse(req, result)
 
-	// validate JSON top-level keys count
-	count, err := jsonvalidate.JsonRootLevelKeyCount(string(body))
-	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "Invalid input")
-	}
-	if count != len(expectedKeys) {
-		return echo.NewHTTPError(http.StatusBadRequest, "Invalid input format, ensure input just totalIncome, wht and allowances")
+	if input.Trace != nil {
+		responseMap["explain"] = input.Trace.Steps
 	}
 
-	// validate JSON order
-	if err := jsonvalidate.CheckJSONOrder(body, expectedKeys); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	// ?details=true เพิ่มเงินได้สุทธิ, ค่าลดหย่อนรวม, effective rate และ marginal bracket
+	if c.QueryParam("details") == "true" {
+		if err := addTaxRateDetails(responseMap, result, language(c)); err != nil {
+			return nil, err
+		}
 	}
 
-	// รวมการ Validate amount ของ struct req  values
-	if err := validityguard.ValidateTaxRequestAmount(validityguard.TaxRequest(*req)); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	// ?installments=true เพิ่มแผนผ่อนชำระ 3 งวด
+	if c.QueryParam("installments") == "true" {
+		responseMap["installmentPlan"] = taxcal.CalculateInstallmentPlan(result)
 	}
 
-	//allowance 3 types เริ่มมาจากค่าเริ่มต้น
-	personalExemption := sharedvars.InitialPersonalExemption
-	donations := sharedvars.Initialdonations
-	kReceipts := sharedvars.InitialkReceipts
-
-	countredundantp := 0 //เพื่อถ้าเกิน 1 ก็คือuserกรอกซ้ำมา
-	countredundantd := 0
-	countredundantk := 0
+	return responseMap, nil
+}
 
-	// loop และแยก allowance 3 types แล้วเทียบ เพื่อได้ค่าที่นำไปใช้ได้
-	for _, allowance := range req.Allowances {
+// buildTaxResponse ประกอบ response ของ tax/calculations จากผลของ taxcal.Calculate
+func buildTaxResponse(req *TaxRequest, result taxcal.TaxResult) map[string]interface{} {
+	response := TaxResponse{Tax: result.TaxPayable, TaxRefund: result.TaxRefund}
 
-		if allowance.AllowanceType == "personal" {
-			countredundantp += 1
-			if countredundantp > 1 {
-				return echo.NewHTTPError(http.StatusBadRequest, "allowanceType personal is redundant, please check and fill again")
-			}
-			if allowance.Amount <= 10000 {
-				return c.JSON(http.StatusBadRequest, echo.Map{"error": "The personal exemption must be more than 10,000 THB.  Please update the amount and try again."})
+	// เปลี่ยน response เป็น map ดึง tax มาจาก response
+	responseMap := map[string]interface{}{
+		"tax": response.Tax,
+	}
 
-			} else {
-				if allowance.Amount > sharedvars.PersonalExemptionUpperLimit {
-					personalExemption = sharedvars.PersonalExemptionUpperLimit
-				}
-			}
-		}
+	// รวม taxRefund เข้า map ถ้า taxRefund ไม่เป็นzero
+	if response.TaxRefund.IsPositive() {
+		responseMap["taxRefund"] = response.TaxRefund
+	}
 
-		if allowance.AllowanceType == "donation" {
-			countredundantd += 1
-			if countredundantd > 1 {
-				return echo.NewHTTPError(http.StatusBadRequest, "allowanceType donation is redundant, please check and fill again")
-			}
-			if allowance.Amount >= 0 {
-				donations += allowance.Amount
-				if donations > sharedvars.DonationsUpperLimit {
-					donations = sharedvars.DonationsUpperLimit
-				}
-			} else {
-				return c.JSON(http.StatusBadRequest, echo.Map{"error": "The donation must be more than 0 THB. Please enter a positive amount and try again."})
-			}
-		}
+	// แสดงค่าใช้จ่ายของเงินได้แต่ละรายการ เมื่อ client ส่ง incomes มา
+	if len(req.Incomes) > 0 {
+		responseMap["incomes"] = result.Incomes
+	}
 
-		if allowance.AllowanceType == "k-receipt" {
-			countredundantk += 1
-			if countredundantk > 1 {
-				return echo.NewHTTPError(http.StatusBadRequest, "allowanceType k-receipt is redundant, please check and fill again")
-			}
-			if allowance.Amount > 0 {
-				kReceipts += allowance.Amount
-				if kReceipts > sharedvars.KReceiptsUpperLimit {
-					kReceipts = sharedvars.KReceiptsUpperLimit
-				}
-			} else {
-				return c.JSON(http.StatusBadRequest, echo.Map{"error": "The kReceipts must be more than 0 THB. Please enter a positive amount and try again."})
-			}
-		}
-		if allowance.AllowanceType != "personal" &&
-			allowance.AllowanceType != "donation" &&
-			allowance.AllowanceType != "k-receipt" {
-			return c.JSON(http.StatusBadRequest, echo.Map{"error": "invalid allowance type. Please ensure the filled type personal, donation ,or k-receipt'"})
+	// แสดงผลการเทียบภาษี 2 วิธี เมื่อมีเงินได้ 40(2)-(8) ถึงเกณฑ์
+	if result.GrossIncomeTaxApplies {
+		responseMap["taxMethod"] = TaxMethodResponse{
+			Method:         result.TaxMethod,
+			ProgressiveTax: result.ProgressiveTax,
+			GrossIncomeTax: result.GrossIncomeTax,
 		}
 	}
 
-	// หา taxable income
-	taxableIncome := taxcal.CaltaxableIncome(req.TotalIncome, personalExemption, donations, kReceipts)
+	// แสดงเงินบริจาคที่หักได้จริงหลังเพดาน 10% และเงินบริจาค 2 เท่า
+	if donations := taxcal.ClaimedDonations(result.Allowances); len(donations) > 0 {
+		responseMap["donations"] = donations
+	}
 
-	// หา taxPayable, taxRefund
-	taxPayable, taxRefund := taxcal.CalculateTaxPayableAndRefund(taxableIncome, req.WHT)
+	// แสดงการเทียบภาษีเงินปันผล 2 วิธี และวิธีที่ใช้ เมื่อ client ส่ง dividends มา
+	if result.DividendTax != nil {
+		responseMap["dividends"] = result.DividendTax
+	}
 
-	response := TaxResponse{Tax: CustomFloat64(taxPayable), TaxRefund: CustomFloat64(taxRefund)}
+	// แสดงภาษีเงินได้ก้อนเดียวที่แยกคำนวน ซึ่งรวมอยู่ใน tax แล้ว
+	if result.Severance != nil {
+		responseMap["severance"] = result.Severance
+	}
 
-	// เปลี่ยน response เป็น map ดึง tax มาจาก response
-	responseMap := map[string]interface{}{
-		"tax": response.Tax,
+	// แสดงเงินเพิ่มและค่าปรับ เมื่อ client ส่ง filingDate มา
+	if result.LateFiling != nil {
+		responseMap["lateFiling"] = result.LateFiling
 	}
 
-	// รวม taxRefund เข้า map ถ้า taxRefund ไม่เป็นzero
-	if response.TaxRefund > 0 {
-		responseMap["taxRefund"] = response.TaxRefund
+	// แสดงค่าลดหย่อนครอบครัวแต่ละชนิด เมื่อ client ส่ง dependants มา
+	if req.Dependants != nil {
+		responseMap["familyAllowances"] = result.FamilyAllowances
 	}
 
 	//applytaxlevel
-	if response.Tax > 0 {
-		//ทำ taxLevelDetails
-		taxLevelDetails := taxcal.CalculateTaxLevelDetails(taxableIncome)
-
+	if response.Tax.IsPositive() {
 		output := map[string]interface{}{
-			"taxLevel": taxLevelDetails,
+			"taxLevel": result.TaxLevels,
 		}
 
 		// ประกอบ responseMap เข้ากับ output ของ taxLevelDetails
@@ -191,5 +149,28 @@ func HandleTaxCalculation(c echo.Context) error {
 		}
 	}
 
-	return c.JSON(http.StatusOK, responseMap)
+	return responseMap
+}
+
+// addTaxRateDetails เพิ่ม field ของ taxcal.TaxRateDetails เข้า responseMap, marginalBracket แสดงเป็นภาษา lang
+func addTaxRateDetails(responseMap map[string]interface{}, result taxcal.TaxResult, lang i18n.Lang) error {
+	details, err := taxcal.CalculateTaxRateDetails(result, lang)
+	if err != nil {
+		return err
+	}
+
+	responseMap["taxableIncome"] = details.TaxableIncome
+	responseMap["totalDeductions"] = details.TotalDeductions
+	responseMap["effectiveTaxRate"] = json.Number(details.EffectiveTaxRate.String())
+	responseMap["marginalBracket"] = details.MarginalBracket
+	responseMap["marginalRate"] = json.Number(details.MarginalRate.String())
+
+	// ขั้นสุดท้ายไม่มีขั้นถัดไป ส่ง null
+	if details.AmountToNextBracket != nil {
+		responseMap["amountToNextBracket"] = *details.AmountToNextBracket
+	} else {
+		responseMap["amountToNextBracket"] = nil
+	}
+
+	return nil
 }
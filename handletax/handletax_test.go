@@ -0,0 +1,199 @@
+package handletax
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/shopspring/decimal"
+	"github.com/windeesel365/assessment-tax/jsonvalidate"
+	"github.com/windeesel365/assessment-tax/money"
+)
+
+// postTaxCalculation ส่ง body ไปที่ tax/calculations ผ่าน middleware ชุดเดียวกับ main แล้ว decode response
+// ตัวเลขใน response เป็น json.Number เพื่อเทียบค่าที่ส่งออกไปจริงโดยไม่ผ่าน float64
+func postTaxCalculation(t *testing.T, target, body string) (int, map[string]interface{}) {
+	t.Helper()
+	e := echo.New()
+	e.HTTPErrorHandler = HTTPErrorHandler
+	e.JSONSerializer = JSONSerializer{}
+	e.Use(Language())
+	e.Use(RoundingPolicy(money.DefaultPolicy()))
+	e.Use(JSONKeyOrder(jsonvalidate.KeyOrderStrict))
+	e.POST("/tax/calculations", HandleTaxCalculation)
+
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	response := map[string]interface{}{}
+	dec := json.NewDecoder(bytes.NewReader(rec.Body.Bytes()))
+	dec.UseNumber()
+	if err := dec.Decode(&response); err != nil {
+		t.Fatalf("decode response %q: %v", rec.Body.String(), err)
+	}
+	return rec.Code, response
+}
+
+// number แปลงตัวเลขใน response เป็น decimal
+func number(t *testing.T, v interface{}) decimal.Decimal {
+	t.Helper()
+	n, ok := v.(json.Number)
+	if !ok {
+		t.Fatalf("%v is not a number", v)
+	}
+	d, err := decimal.NewFromString(n.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func TestHandleTaxCalculationInstallmentsRounding(t *testing.T) {
+	body := `{"totalIncome":600000.0,"wht":0.0,"allowances":[{"allowanceType":"donation","amount":0.0}]}`
+	tests := []struct {
+		name   string
+		target string
+	}{
+		{"Default policy", "/tax/calculations?installments=true"},
+		{"Request rounding", "/tax/calculations?installments=true&rounding=down:0"},
+		{"Request rounding of tax only", "/tax/calculations?installments=true&rounding=down:0&rounding.tax=half-up:2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, response := postTaxCalculation(t, tt.target, body)
+			if status != http.StatusOK {
+				t.Fatalf("status = %d, response = %v", status, response)
+			}
+
+			plan := response["installmentPlan"].(map[string]interface{})
+			total := decimal.Zero
+			for _, installment := range plan["installments"].([]interface{}) {
+				total = total.Add(number(t, installment.(map[string]interface{})["amount"]))
+			}
+			if tax := number(t, response["tax"]); !total.Equal(tax) {
+				t.Errorf("installments total = %s, want tax %s", total, tax)
+			}
+		})
+	}
+}
+
+func TestHandleTaxCalculationLateFiling(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		tax          string
+		taxRefund    string
+		totalPayable string
+	}{
+		{
+			name:         "Surcharge and penalty added to tax",
+			body:         `{"totalIncome":500000.0,"wht":0.0,"allowances":[{"allowanceType":"donation","amount":0.0}],"filingDate":"2025-05-01","paymentDate":"2025-06-15"}`,
+			tax:          "30705",
+			totalPayable: "30705",
+		},
+		{
+			name:         "Penalty offset against refund",
+			body:         `{"totalIncome":500000.0,"wht":40000.0,"allowances":[{"allowanceType":"donation","amount":0.0}],"filingDate":"2025-05-01"}`,
+			tax:          "0",
+			taxRefund:    "10600",
+			totalPayable: "0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, response := postTaxCalculation(t, "/tax/calculations", tt.body)
+			if status != http.StatusOK {
+				t.Fatalf("status = %d, response = %v", status, response)
+			}
+
+			if got := number(t, response["tax"]); !got.Equal(decimal.RequireFromString(tt.tax)) {
+				t.Errorf("tax = %s, want %s", got, tt.tax)
+			}
+			if tt.taxRefund == "" {
+				if _, ok := response["taxRefund"]; ok {
+					t.Errorf("taxRefund = %v, want none", response["taxRefund"])
+				}
+			} else if got := number(t, response["taxRefund"]); !got.Equal(decimal.RequireFromString(tt.taxRefund)) {
+				t.Errorf("taxRefund = %s, want %s", got, tt.taxRefund)
+			}
+			lateFiling := response["lateFiling"].(map[string]interface{})
+			if got := number(t, lateFiling["totalPayable"]); !got.Equal(decimal.RequireFromString(tt.totalPayable)) {
+				t.Errorf("lateFiling.totalPayable = %s, want %s", got, tt.totalPayable)
+			}
+		})
+	}
+}
+
+func TestHandleTaxCalculationDetailsRates(t *testing.T) {
+	body := `{"totalIncome":500000.0,"wht":0.0,"allowances":[{"allowanceType":"donation","amount":0.0}]}`
+	status, response := postTaxCalculation(t, "/tax/calculations?details=true", body)
+	if status != http.StatusOK {
+		t.Fatalf("status = %d, response = %v", status, response)
+	}
+
+	// อัตราต้องส่งเป็นตัวเลขทศนิยมตรงตามที่คำนวน ไม่ผ่าน float64
+	tests := []struct {
+		key  string
+		want string
+	}{
+		{"effectiveTaxRate", "0.058"},
+		{"marginalRate", "0.1"},
+	}
+	for _, tt := range tests {
+		if got, ok := response[tt.key].(json.Number); !ok || !number(t, got).Equal(decimal.RequireFromString(tt.want)) {
+			t.Errorf("%s = %v, want %s", tt.key, response[tt.key], tt.want)
+		}
+	}
+}
+
+func TestHandleTaxCalculationExplainPersonal(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   string
+		wantRule bool
+		limit    int64
+		output   int64
+	}{
+		{"Claim within limit gets initial exemption", "70000.0", true, 60000, 60000},
+		{"Claim below initial exemption gets initial exemption", "20000.0", true, 60000, 60000},
+		{"Claim above limit is capped", "150000.0", false, 100000, 100000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := `{"totalIncome":500000.0,"wht":0.0,"allowances":[{"allowanceType":"personal","amount":` + tt.amount + `}]}`
+			status, response := postTaxCalculation(t, "/tax/calculations?explain=true", body)
+			if status != http.StatusOK {
+				t.Fatalf("status = %d, response = %v", status, response)
+			}
+
+			// ยอดที่ได้ต่างจากที่ยื่นโดยไม่ใช่เพราะเพดาน ต้องมี rule อธิบาย และ limit เป็นยอดที่ได้จริง
+			for _, step := range response["explain"].([]interface{}) {
+				step := step.(map[string]interface{})
+				if step["step"] != "allowance" || step["name"] != "personal" {
+					continue
+				}
+				rule := step["rule"].(string)
+				if got := strings.Contains(rule, "claims up to 100,000 get 60,000"); got != tt.wantRule {
+					t.Errorf("rule = %q, want claims up to 100,000 get 60,000: %v", rule, tt.wantRule)
+				}
+				if limit := number(t, step["limit"]); !limit.Equal(decimal.NewFromInt(tt.limit)) {
+					t.Errorf("limit = %s, want %d", limit, tt.limit)
+				}
+				if output := number(t, step["output"]); !output.Equal(decimal.NewFromInt(tt.output)) {
+					t.Errorf("output = %s, want %d", output, tt.output)
+				}
+				return
+			}
+			t.Errorf("explain has no personal allowance step: %v", response["explain"])
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package handletax
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/windeesel365/assessment-tax/apierror"
+)
+
+// HTTPErrorHandler ส่ง error ของทุก endpoint เป็น application/problem+json ตาม RFC 7807 ในภาษาของ request
+// requestId มาจาก header X-Request-ID ที่ middleware.RequestID กำหนด เพื่อให้ client อ้างอิงกับ log ได้
+// error 5xx ถูก log พร้อมสาเหตุภายใน ซึ่งไม่ส่งให้ client
+func HTTPErrorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+
+	requestID := requestID(c)
+	problem := apierror.NewProblem(err, c.Request().URL.Path, requestID, language(c))
+	if problem.Status >= http.StatusInternalServerError {
+		c.Logger().Errorf("request %s: %v", requestID, err)
+	}
+
+	if c.Request().Method == http.MethodHead {
+		err = c.NoContent(problem.Status)
+	} else {
+		c.Response().Header().Set(echo.HeaderContentType, apierror.ContentType)
+		c.Response().WriteHeader(problem.Status)
+		err = json.NewEncoder(c.Response()).Encode(problem)
+	}
+	if err != nil {
+		c.Logger().Error(err)
+	}
+}
+
+// requestID คืน id ของ request จาก middleware.RequestID หรือจาก client ถ้าไม่ได้ใช้ middleware
+func requestID(c echo.Context) string {
+	if id := c.Response().Header().Get(echo.HeaderXRequestID); id != "" {
+		return id
+	}
+	return c.Request().Header.Get(echo.HeaderXRequestID)
+}
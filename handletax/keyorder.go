@@ -0,0 +1,43 @@
+package handletax
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/windeesel365/assessment-tax/apierror"
+	"github.com/windeesel365/assessment-tax/jsonvalidate"
+)
+
+// keyOrderKey คือ key ใน echo.Context ที่เก็บ jsonvalidate.KeyOrder ของ request
+const keyOrderKey = "keyOrder"
+
+// KeyOrderHeader คือ header สำหรับเลือกโหมดลำดับ key ราย request: strict หรือ any
+const KeyOrderHeader = "X-JSON-Key-Order"
+
+// JSONKeyOrder middleware เลือกโหมดลำดับ key ของ request: โหมดของ server ทับด้วย KeyOrderHeader
+// การเช็ค key ซ้ำและ key ที่ไม่รู้จักยังทำเหมือนเดิมทั้งสองโหมด
+func JSONKeyOrder(server jsonvalidate.KeyOrder) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			order := server
+			if value := c.Request().Header.Get(KeyOrderHeader); value != "" {
+				parsed, err := jsonvalidate.ParseKeyOrder(value)
+				if err != nil {
+					e := apierror.BadRequestf(apierror.CodeInvalidParameter, "%s: %v", KeyOrderHeader, err)
+					e.Field = KeyOrderHeader
+					return e
+				}
+				order = parsed
+			}
+
+			c.Set(keyOrderKey, order)
+			return next(c)
+		}
+	}
+}
+
+// keyOrder คืนโหมดลำดับ key ของ request, request ที่ไม่ผ่าน JSONKeyOrder ใช้ contract เดิม
+func keyOrder(c echo.Context) jsonvalidate.KeyOrder {
+	if order, ok := c.Get(keyOrderKey).(jsonvalidate.KeyOrder); ok {
+		return order
+	}
+	return jsonvalidate.KeyOrderStrict
+}
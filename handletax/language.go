@@ -0,0 +1,56 @@
+package handletax
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/windeesel365/assessment-tax/apierror"
+	"github.com/windeesel365/assessment-tax/i18n"
+)
+
+// languageKey คือ key ใน echo.Context ที่เก็บ i18n.Lang ของ request
+const languageKey = "language"
+
+// header ของภาษาซึ่ง echo ไม่ได้ประกาศไว้
+const (
+	headerAcceptLanguage  = "Accept-Language"
+	headerContentLanguage = "Content-Language"
+)
+
+// languageParam คือ query parameter สำหรับเลือกภาษาราย request ซึ่งมาก่อน header Accept-Language
+const languageParam = "lang"
+
+// Language middleware เลือกภาษาของ error และ label ใน response จาก ?lang= หรือ header Accept-Language
+// ไม่ระบุภาษาใช้ข้อความต้นฉบับตาม contract เดิม, ?lang= ที่ไม่รองรับตอบ 400
+func Language() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			lang := i18n.FromAcceptLanguage(c.Request().Header.Get(headerAcceptLanguage))
+			if value := c.QueryParam(languageParam); value != "" {
+				parsed, ok := i18n.Parse(value)
+				if !ok {
+					e := apierror.BadRequestf(apierror.CodeInvalidParameter, "%s must be %s or %s", languageParam, i18n.English, i18n.Thai)
+					e.Field = languageParam
+					return e
+				}
+				lang = parsed
+			}
+
+			c.Set(languageKey, lang)
+			if lang != "" {
+				c.Response().Header().Set(headerContentLanguage, string(lang))
+			}
+			return next(c)
+		}
+	}
+}
+
+// language คืนภาษาของ request, request ที่ไม่ผ่าน Language เช่น error ของ ?lang= เอง
+// เลือกจาก ?lang= ที่รองรับหรือ Accept-Language โดยตรง
+func language(c echo.Context) i18n.Lang {
+	if lang, ok := c.Get(languageKey).(i18n.Lang); ok {
+		return lang
+	}
+	if lang, ok := i18n.Parse(c.QueryParam(languageParam)); ok {
+		return lang
+	}
+	return i18n.FromAcceptLanguage(c.Request().Header.Get(headerAcceptLanguage))
+}
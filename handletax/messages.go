@@ -0,0 +1,11 @@
+package handletax
+
+import "github.com/windeesel365/assessment-tax/i18n"
+
+// คำแปลภาษาไทยของ error ใน package handletax, "Invalid input" ลงทะเบียนไว้ใน apierror
+func init() {
+	i18n.Register(i18n.Thai, map[string]string{
+		"Invalid input format, ensure input just totalIncome, wht and allowances": "รูปแบบข้อมูลไม่ถูกต้อง กรุณาใส่เฉพาะ totalIncome, wht และ allowances",
+		"%s must be %s or %s": "%s ต้องเป็น %s หรือ %s",
+	})
+}
@@ -0,0 +1,79 @@
+package handletax
+
+import (
+	"github.com/windeesel365/assessment-tax/apierror"
+	"github.com/windeesel365/assessment-tax/i18n"
+	"github.com/windeesel365/assessment-tax/jsonvalidate"
+	"github.com/windeesel365/assessment-tax/money"
+	"github.com/windeesel365/assessment-tax/taxcal"
+	"github.com/windeesel365/assessment-tax/validityguard"
+)
+
+// data structure pattern ที่ user client request
+type TaxRequest struct {
+	TotalIncome    money.Money             `json:"totalIncome"`
+	WHT            money.Money             `json:"wht"`
+	Allowances     []taxcal.AllowanceClaim `json:"allowances"`
+	TaxYear        int                     `json:"taxYear,omitempty"`
+	Dependants     *taxcal.Dependants      `json:"dependants,omitempty"`
+	Incomes        []taxcal.Income         `json:"incomes,omitempty"`
+	FilingDate     *taxcal.Date            `json:"filingDate,omitempty"`
+	PaymentDate    *taxcal.Date            `json:"paymentDate,omitempty"`
+	Dividends      []taxcal.Dividend       `json:"dividends,omitempty"`
+	DividendMethod string                  `json:"dividendMethod,omitempty"`
+	Severance      *taxcal.Severance       `json:"severance,omitempty"`
+}
+
+// taxInput แปลง TaxRequest เป็น input ของ taxcal.Calculate โดย label แสดงเป็นภาษา lang
+func (req *TaxRequest) taxInput(lang i18n.Lang) taxcal.TaxInput {
+	return taxcal.TaxInput{
+		TaxYear:        req.TaxYear,
+		TotalIncome:    req.TotalIncome,
+		Incomes:        req.Incomes,
+		WHT:            req.WHT,
+		Allowances:     req.Allowances,
+		Dependants:     req.Dependants,
+		FilingDate:     req.FilingDate,
+		PaymentDate:    req.PaymentDate,
+		Dividends:      req.Dividends,
+		DividendMethod: req.DividendMethod,
+		Severance:      req.Severance,
+		Lang:           lang,
+	}
+}
+
+// parseTaxRequest validate body ของ TaxRequest แล้ว bind เป็น struct
+// order คือโหมดลำดับ key จาก JSONKeyOrder
+// error เป็น *apierror.Error ยกเว้น error จาก taxcal ซึ่ง handler แปลงด้วย apierror.Validation
+func parseTaxRequest(body []byte, order jsonvalidate.KeyOrder) (*TaxRequest, error) {
+	// decode แบบ strict: key ซ้ำทุกระดับ, field ที่ไม่รู้จัก, ชนิดข้อมูลผิด และข้อมูลเกิน รายงานพร้อม path ทั้งหมดในครั้งเดียว
+	req := new(TaxRequest)
+	if err := jsonvalidate.StrictDecode(body, req); err != nil {
+		return nil, apierror.Decode(err)
+	}
+
+	// expected key order ที่ถูกต้อง เพื่อใช้ validate JSON order
+	expectedKeys := []string{"totalIncome", "wht", "allowances"}
+	// optional keys ต้องตามหลัง expectedKeys ในโหมด strict
+	optionalKeys := []string{"taxYear", "dependants", "incomes", "filingDate", "paymentDate", "dividends", "dividendMethod", "severance"}
+
+	// validate JSON top-level keys count
+	count, err := jsonvalidate.JsonRootLevelKeyCount(string(body))
+	if err != nil {
+		return nil, apierror.BadRequest(apierror.CodeInvalidJSON, "Invalid input")
+	}
+	if count < len(expectedKeys) {
+		return nil, apierror.BadRequest(apierror.CodeInvalidKeys, "Invalid input format, ensure input just totalIncome, wht and allowances")
+	}
+	// validate key ครบ/ไม่ซ้ำ/ไม่เกิน และลำดับ key ตามโหมด order
+	if err := jsonvalidate.CheckRootKeys(body, expectedKeys, optionalKeys, order); err != nil {
+		return nil, apierror.BadRequestf(apierror.CodeInvalidKeys, "%v", err)
+	}
+
+	// รวมการ Validate amount ของ struct req  values
+	if err := validityguard.ValidateTaxRequestAmount(validityguard.TaxRequest(*req)); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
@@ -0,0 +1,61 @@
+package handletax
+
+import (
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/windeesel365/assessment-tax/apierror"
+	"github.com/windeesel365/assessment-tax/money"
+)
+
+// roundingPolicyKey คือ key ใน echo.Context ที่เก็บ money.Policy ของ request
+const roundingPolicyKey = "roundingPolicy"
+
+// roundingParam คือ query parameter สำหรับกำหนดรูปแบบจำนวนเงินราย request
+// ?rounding=half-up:2 ใช้กับทุกจำนวนเงิน, ?rounding.tax=down:0 ใช้เฉพาะ key tax
+const roundingParam = "rounding"
+
+// RoundingPolicy middleware เลือก money.Policy ของ request: policy ของ server ทับด้วย query parameter
+// ใช้คู่กับ JSONSerializer เพื่อให้ทุก endpoint ปัดเศษจำนวนเงินแบบเดียวกัน
+func RoundingPolicy(server money.Policy) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			var defaultFormat *money.Format
+			fields := map[string]money.Format{}
+			for name, values := range c.QueryParams() {
+				if name != roundingParam && !strings.HasPrefix(name, roundingParam+".") {
+					continue
+				}
+				f, err := money.ParseFormat(values[len(values)-1])
+				if err != nil {
+					e := apierror.BadRequestf(apierror.CodeInvalidParameter, "%v", err)
+					e.Field = name
+					return e
+				}
+				if name == roundingParam {
+					defaultFormat = &f
+				} else {
+					fields[strings.TrimPrefix(name, roundingParam+".")] = f
+				}
+			}
+
+			c.Set(roundingPolicyKey, server.Override(defaultFormat, fields))
+			return next(c)
+		}
+	}
+}
+
+// JSONSerializer ใช้ money.Policy ของ request กับจำนวนเงินทุกค่าก่อน encode
+// request ที่ไม่ผ่าน RoundingPolicy ใช้ money.DefaultPolicy
+type JSONSerializer struct {
+	echo.DefaultJSONSerializer
+}
+
+// Serialize ปัดเศษจำนวนเงินใน i ตาม policy แล้ว encode ด้วย encoding/json
+func (s JSONSerializer) Serialize(c echo.Context, i interface{}, indent string) error {
+	policy, ok := c.Get(roundingPolicyKey).(money.Policy)
+	if !ok {
+		policy = money.DefaultPolicy()
+	}
+	return s.DefaultJSONSerializer.Serialize(c, policy.Apply(i), indent)
+}
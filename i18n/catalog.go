@@ -0,0 +1,52 @@
+package i18n
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// catalog เก็บคำแปลตาม Lang โดย key คือข้อความต้นฉบับ
+// ข้อความที่ไม่มีคำแปลของภาษานั้นแสดงตามต้นฉบับ เช่น error ภาษาอังกฤษเมื่อเลือก English
+var catalog = map[Lang]map[string]string{}
+
+// verbPattern หา format verb เช่น %s, %d, %q, %5.2f เพื่อเช็คว่าคำแปลใช้ verb ชุดเดียวกัน
+var verbPattern = regexp.MustCompile(`%[-+# 0]*[0-9]*(\.[0-9]+)?[a-zA-Z%]`)
+
+// Register ลงทะเบียนคำแปลภาษา lang ของข้อความต้นฉบับ แต่ละ package ลงทะเบียนข้อความของตัวเองใน init
+// คำแปลที่ใช้ verb ไม่ตรงกับต้นฉบับ หรือ key เดียวกันที่แปลต่างกัน ถือเป็น programming error
+func Register(lang Lang, messages map[string]string) {
+	if catalog[lang] == nil {
+		catalog[lang] = map[string]string{}
+	}
+	for source, translation := range messages {
+		if !sameVerbs(source, translation) {
+			panic(fmt.Sprintf("i18n: %s translation of %q has different format verbs: %q", lang, source, translation))
+		}
+		if existing, ok := catalog[lang][source]; ok && existing != translation {
+			panic(fmt.Sprintf("i18n: %s translation of %q registered twice", lang, source))
+		}
+		catalog[lang][source] = translation
+	}
+}
+
+// lookup หาคำแปลของ source, ไม่มีคำแปลคืน source
+func lookup(source string, lang Lang) string {
+	if translation, ok := catalog[lang][source]; ok {
+		return translation
+	}
+	return source
+}
+
+// sameVerbs เช็คว่าคำแปลใช้ format verb ชุดเดียวกับต้นฉบับตามลำดับ เพราะ Args แทนตามตำแหน่ง
+func sameVerbs(source, translation string) bool {
+	a, b := verbPattern.FindAllString(source, -1), verbPattern.FindAllString(translation, -1)
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,45 @@
+package i18n
+
+import "testing"
+
+func TestSameVerbs(t *testing.T) {
+	tests := []struct {
+		source, translation string
+		want                bool
+	}{
+		{"%s must be between %d and %d", "%s ต้องอยู่ระหว่าง %d ถึง %d", true},
+		{"amount must be greater than 0", "amount ต้องมากกว่า 0", true},
+		{"%s is redundant", "ซ้ำกัน", false},
+		{"%s year %d", "ปี %d ของ %s", false},
+		{"100%% done", "เสร็จ 100%%", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.source, func(t *testing.T) {
+			if got := sameVerbs(tt.source, tt.translation); got != tt.want {
+				t.Errorf("sameVerbs(%q, %q) = %v, want %v", tt.source, tt.translation, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegisterPanics(t *testing.T) {
+	tests := []struct {
+		name     string
+		messages map[string]string
+	}{
+		{"Different verbs", map[string]string{"taxYear %d is not supported": "ไม่รองรับปีภาษี %s"}},
+		{"Conflicting translation", map[string]string{"Not Found": "ไม่พบ"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Register() did not panic")
+				}
+			}()
+			Register(Thai, tt.messages)
+		})
+	}
+}
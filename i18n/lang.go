@@ -0,0 +1,71 @@
+// Package i18n คือ message catalog ภาษาไทย/อังกฤษ ของทุกข้อความที่ผู้ใช้เห็น
+package i18n
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Lang คือภาษาของข้อความ, ค่าว่างคือข้อความต้นฉบับตามที่เขียนไว้ในโค้ด
+// (error เป็นภาษาอังกฤษ และ label ของขั้นบันใดภาษีเป็นภาษาไทย ตาม contract เดิม)
+type Lang string
+
+const (
+	English Lang = "en"
+	Thai    Lang = "th"
+)
+
+// Languages คือภาษาที่ catalog รองรับ
+func Languages() []Lang {
+	return []Lang{English, Thai}
+}
+
+// Parse แปลง language tag เช่น th, th-TH หรือ en_US เป็น Lang, ok เป็น false เมื่อไม่รองรับ
+func Parse(tag string) (Lang, bool) {
+	primary := strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(primary, "-_"); i >= 0 {
+		primary = primary[:i]
+	}
+	for _, lang := range Languages() {
+		if primary == string(lang) {
+			return lang, true
+		}
+	}
+	return "", false
+}
+
+// FromAcceptLanguage เลือกภาษาที่รองรับซึ่งมี q สูงสุดจาก header Accept-Language
+// q เท่ากันเลือกตัวที่มาก่อน, ไม่มีภาษาที่รองรับคืนค่าว่าง
+func FromAcceptLanguage(header string) Lang {
+	type candidate struct {
+		lang Lang
+		q    float64
+	}
+	var candidates []candidate
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		lang, ok := Parse(fields[0])
+		if !ok {
+			continue
+		}
+		q := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if value, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					q = value
+				}
+			}
+		}
+		if q > 0 {
+			candidates = append(candidates, candidate{lang, q})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+	if len(candidates) == 0 {
+		return ""
+	}
+	return candidates[0].lang
+}
@@ -0,0 +1,50 @@
+package i18n
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		tag    string
+		want   Lang
+		wantOK bool
+	}{
+		{"th", Thai, true},
+		{"th-TH", Thai, true},
+		{"EN_us", English, true},
+		{" en ", English, true},
+		{"ja", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			got, ok := Parse(tt.tag)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("Parse(%q) = %q, %v, want %q, %v", tt.tag, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestFromAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		header string
+		want   Lang
+	}{
+		{"th-TH,th;q=0.9,en;q=0.8", Thai},
+		{"en-US,en;q=0.9", English},
+		{"ja,en;q=0.5,th;q=0.7", Thai},
+		{"th;q=0.5,en;q=0.5", Thai},
+		{"th;q=0,en;q=0.1", English},
+		{"fr-CH, fr;q=0.9, *;q=0.5", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			if got := FromAcceptLanguage(tt.header); got != tt.want {
+				t.Errorf("FromAcceptLanguage(%q) = %q, want %q", tt.header, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Localizer คือค่าที่แสดงเป็นข้อความตามภาษาได้ เช่น Message หรือ error ที่สร้างจาก Errorf
+type Localizer interface {
+	In(lang Lang) string
+}
+
+// Message คือข้อความที่แปลได้: Format คือข้อความต้นฉบับซึ่งเป็น key ของ catalog และ Args คือค่าที่แทนใน Format
+// Args ที่เป็น Localizer หรือ error ถูกแปลเป็นภาษาเดียวกันด้วย
+type Message struct {
+	Format string
+	Args   []interface{}
+	// literal เป็น true เมื่อ Format ไม่ใช่ format string เช่น ข้อความจาก echo ซึ่งอาจมี %
+	literal bool
+}
+
+// Msg สร้าง Message จาก format แบบ fmt.Sprintf
+func Msg(format string, args ...interface{}) Message {
+	return Message{Format: format, Args: args}
+}
+
+// Text สร้าง Message จากข้อความที่ไม่มี format verb
+func Text(text string) Message {
+	return Message{Format: text, literal: true}
+}
+
+// In แสดงข้อความเป็นภาษา lang, ข้อความที่ไม่มีใน catalog แสดงตามต้นฉบับ
+func (m Message) In(lang Lang) string {
+	format := lookup(m.Format, lang)
+	if m.literal {
+		return format
+	}
+
+	args := make([]interface{}, len(m.Args))
+	for i, arg := range m.Args {
+		switch arg := arg.(type) {
+		case Localizer:
+			args[i] = arg.In(lang)
+		case error:
+			args[i] = Translate(arg, lang)
+		default:
+			args[i] = arg
+		}
+	}
+	// %w ของ Errorf แสดงแบบ %v เพราะ error ถูกแปลเป็น string แล้ว
+	return fmt.Sprintf(strings.ReplaceAll(format, "%w", "%v"), args...)
+}
+
+// String แสดงข้อความต้นฉบับ
+func (m Message) String() string {
+	return m.In("")
+}
+
+// Error คือ error ที่เก็บ Message ไว้แปลภายหลัง, Error() คือข้อความต้นฉบับเหมือน fmt.Errorf
+type Error struct {
+	Message
+}
+
+// Errorf สร้าง error แบบ fmt.Errorf ที่แปลได้ด้วย Translate, รองรับ %w
+func Errorf(format string, args ...interface{}) error {
+	return &Error{Msg(format, args...)}
+}
+
+func (e *Error) Error() string {
+	return e.String()
+}
+
+// Unwrap คืน error ที่ใส่ด้วย %w
+func (e *Error) Unwrap() error {
+	if !strings.Contains(e.Format, "%w") {
+		return nil
+	}
+	for _, arg := range e.Args {
+		if err, ok := arg.(error); ok {
+			return err
+		}
+	}
+	return nil
+}
+
+// Translate แสดง err เป็นภาษา lang
+// err ที่เป็น Localizer หรือสร้างจาก Errorf แปลตาม catalog, error อื่นแสดงตาม err.Error()
+func Translate(err error, lang Lang) string {
+	if localizer, ok := err.(Localizer); ok {
+		return localizer.In(lang)
+	}
+	// error ที่ห่อด้วย fmt.Errorf แปลได้เฉพาะเมื่อไม่มีข้อความเพิ่ม
+	var e *Error
+	if errors.As(err, &e) && e.Error() == err.Error() {
+		return e.In(lang)
+	}
+	return err.Error()
+}
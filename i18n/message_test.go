@@ -0,0 +1,82 @@
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func init() {
+	Register(Thai, map[string]string{
+		"amount must be a non-negative value": "amount ต้องไม่ติดลบ",
+		"%s must be between %d and %d":        "%s ต้องอยู่ระหว่าง %d ถึง %d",
+		"incomes[%d]: %w":                     "incomes[%d]: %w",
+		"Not Found":                           "ไม่พบข้อมูล",
+	})
+	Register(English, map[string]string{
+		"%s ขึ้นไป": "%s and over",
+	})
+}
+
+func TestMessageIn(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  Message
+		lang Lang
+		want string
+	}{
+		{"Source", Msg("%s must be between %d and %d", "month", 1, 12), "", "month must be between 1 and 12"},
+		{"English source", Msg("%s must be between %d and %d", "month", 1, 12), English, "month must be between 1 and 12"},
+		{"Thai", Msg("%s must be between %d and %d", "month", 1, 12), Thai, "month ต้องอยู่ระหว่าง 1 ถึง 12"},
+		{"Thai source label", Msg("%s ขึ้นไป", "2,000,001"), "", "2,000,001 ขึ้นไป"},
+		{"Thai source label in English", Msg("%s ขึ้นไป", "2,000,001"), English, "2,000,001 and over"},
+		{"Missing translation", Msg("unknown %s", "x"), Thai, "unknown x"},
+		{"Localizer arg", Msg("%s: %s", "scenarios[0]", Text("Not Found")), Thai, "scenarios[0]: ไม่พบข้อมูล"},
+		{"Error arg", Msg("incomes[%d]: %w", 0, Errorf("amount must be a non-negative value")), Thai, "incomes[0]: amount ต้องไม่ติดลบ"},
+		{"Literal text", Text("100% Not Found"), Thai, "100% Not Found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.msg.In(tt.lang); got != tt.want {
+				t.Errorf("In(%q) = %q, want %q", tt.lang, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestErrorf(t *testing.T) {
+	cause := Errorf("amount must be a non-negative value")
+	err := Errorf("incomes[%d]: %w", 2, cause)
+
+	if want := fmt.Errorf("incomes[%d]: %w", 2, cause).Error(); err.Error() != want {
+		t.Errorf("Errorf().Error() = %q, want %q", err.Error(), want)
+	}
+	if !errors.Is(err, cause) {
+		t.Errorf("Errorf() does not unwrap %%w")
+	}
+	if got := Translate(err, Thai); got != "incomes[2]: amount ต้องไม่ติดลบ" {
+		t.Errorf("Translate() = %q", got)
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"Errorf", Errorf("amount must be a non-negative value"), "amount ต้องไม่ติดลบ"},
+		{"Plain error", errors.New("amount must be a non-negative value"), "amount must be a non-negative value"},
+		{"Wrapped with extra text", fmt.Errorf("row 1: %w", Errorf("amount must be a non-negative value")), "row 1: amount must be a non-negative value"},
+		{"Wrapped without extra text", fmt.Errorf("%w", Errorf("amount must be a non-negative value")), "amount ต้องไม่ติดลบ"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Translate(tt.err, Thai); got != tt.want {
+				t.Errorf("Translate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
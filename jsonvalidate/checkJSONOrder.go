@@ -1,8 +1,9 @@
 package jsonvalidate
 
 import (
-	"fmt"
 	"strings"
+
+	"github.com/windeesel365/assessment-tax/i18n"
 )
 
 // checkJSONOrder checks if the keys in the provided JSON body are in the expected order.
@@ -10,26 +11,26 @@ func CheckJSONOrder(body []byte, expectedKeys []string) error {
 
 	keys, err := GetOrderedKeysFromJSON(body)
 	if err != nil {
-		fmt.Println("Error:", err)
+		return i18n.Errorf(invalidJSONMessage+"%s", err)
 	}
 
-	keys = keys[0:len(expectedKeys)]
+	// key ที่เกินมาไม่นำมาเทียบ ส่วน key ที่ขาดไปแจ้งด้วยจำนวน key ด้านล่าง
+	if len(keys) > len(expectedKeys) {
+		keys = keys[0:len(expectedKeys)]
+	}
 
 	expectedKeysMembers := strings.Join(expectedKeys, ", ")
 
 	if len(keys) != len(expectedKeys) {
-		return fmt.Errorf("please enter just %d key(s) ordered by: %s. Then process again", len(expectedKeys), expectedKeysMembers)
+		return i18n.Errorf("please enter just %d key(s) ordered by: %s. Then process again", len(expectedKeys), expectedKeysMembers)
 	}
 
 	for i, key := range keys {
 		if key != expectedKeys[i] {
 			//if key != expectedKeys[i] && !unexpectedform {
-			return fmt.Errorf("please enter data in correct order, key name: %s. Then process again", expectedKeysMembers)
+			return i18n.Errorf("please enter data in correct order, key name: %s. Then process again", expectedKeysMembers)
 		}
 	}
 
-	// clear keys after processing
-	keys = nil
-
 	return nil
 }
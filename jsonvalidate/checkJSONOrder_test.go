@@ -24,6 +24,18 @@ func TestCheckJSONOrder(t *testing.T) {
 			expectedKeys: []string{"key1", "key2"},
 			wantErr:      true,
 		},
+		{
+			name:         "MissingKeys",
+			body:         []byte(`{"key1": "value1"}`),
+			expectedKeys: []string{"key1", "key2"},
+			wantErr:      true,
+		},
+		{
+			name:         "InvalidJSON",
+			body:         []byte(`{"key1": `),
+			expectedKeys: []string{"key1", "key2"},
+			wantErr:      true,
+		},
 	}
 
 	// Run tests
@@ -0,0 +1,40 @@
+package jsonvalidate
+
+import (
+	"strings"
+
+	"github.com/windeesel365/assessment-tax/i18n"
+)
+
+// CheckKeySet เช็ค root-level keys โดยไม่สนลำดับ
+// ต้องมี expectedKeys ครบ และ key ทุกตัวต้องเป็น expectedKeys หรือ optionalKeys ที่ไม่ซ้ำกัน
+func CheckKeySet(body []byte, expectedKeys, optionalKeys []string) error {
+	keys, err := JsonRootLevelKeys(body)
+	if err != nil {
+		return err
+	}
+
+	seen := map[string]bool{}
+	for _, key := range keys {
+		if !contains(expectedKeys, key) && !contains(optionalKeys, key) {
+			return i18n.Errorf("unknown key name: %s. Then process again", key)
+		}
+		if seen[key] {
+			return i18n.Errorf("input data '%s' more than once, check and fill again", key)
+		}
+		seen[key] = true
+	}
+
+	var missing []string
+	for _, key := range expectedKeys {
+		if !seen[key] {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		return i18n.Errorf("please enter key(s) %s, missing %s. Then process again",
+			strings.Join(expectedKeys, ", "), strings.Join(missing, ", "))
+	}
+
+	return nil
+}
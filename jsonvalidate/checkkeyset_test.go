@@ -0,0 +1,36 @@
+package jsonvalidate
+
+import "testing"
+
+func TestCheckKeySet(t *testing.T) {
+	expected := []string{"totalIncome", "wht", "allowances"}
+	optional := []string{"taxYear", "incomes"}
+
+	tests := []struct {
+		name    string
+		body    string
+		wantErr string
+	}{
+		{"Only expected keys", `{"totalIncome":1,"wht":0,"allowances":[]}`, ""},
+		{"Any order with optional keys", `{"incomes":[],"allowances":[],"taxYear":2567,"wht":0,"totalIncome":1}`, ""},
+		{"Missing keys", `{"allowances":[]}`, "please enter key(s) totalIncome, wht, allowances, missing totalIncome, wht. Then process again"},
+		{"Unknown key", `{"totalIncome":1,"year":2567,"wht":0,"allowances":[]}`, "unknown key name: year. Then process again"},
+		{"Duplicate expected key", `{"totalIncome":1,"wht":0,"allowances":[],"totalIncome":2}`, "input data 'totalIncome' more than once, check and fill again"},
+		{"JSON array", `[1,2]`, "JSON body must be an object"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckKeySet([]byte(tt.body), expected, optional)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("CheckKeySet() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("CheckKeySet() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
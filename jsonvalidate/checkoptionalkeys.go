@@ -0,0 +1,75 @@
+package jsonvalidate
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/windeesel365/assessment-tax/i18n"
+)
+
+// JsonRootLevelKeys หา JSON top-level keys เรียงตามลำดับใน body (รวม key ที่ซ้ำด้วย)
+func JsonRootLevelKeys(body []byte) ([]string, error) {
+	decoder := json.NewDecoder(strings.NewReader(string(body)))
+
+	// token แรกต้องเป็น '{'
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return nil, i18n.Errorf("JSON body must be an object")
+	}
+
+	var keys []string
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, token.(string))
+
+		// skip value ทั้งก้อน (รวม nested object/array)
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
+}
+
+// CheckOptionalKeys เช็คว่า root-level keys มี expectedKeys ครบ
+// และ key ที่เกินมาต้องเป็น optionalKeys ที่ไม่ซ้ำกันเท่านั้น
+// ลำดับของ expectedKeys ยังต้องเช็คแยกด้วย CheckJSONOrder
+func CheckOptionalKeys(body []byte, expectedKeys, optionalKeys []string) error {
+	keys, err := JsonRootLevelKeys(body)
+	if err != nil {
+		return err
+	}
+
+	if len(keys) < len(expectedKeys) || len(keys) > len(expectedKeys)+len(optionalKeys) {
+		return i18n.Errorf("please enter key(s) %s, optionally followed by %s. Then process again",
+			strings.Join(expectedKeys, ", "), strings.Join(optionalKeys, ", "))
+	}
+
+	seen := map[string]bool{}
+	for _, key := range keys[len(expectedKeys):] {
+		if !contains(optionalKeys, key) {
+			return i18n.Errorf("unknown key name: %s. Then process again", key)
+		}
+		if seen[key] {
+			return i18n.Errorf("input data '%s' more than once, check and fill again", key)
+		}
+		seen[key] = true
+	}
+
+	return nil
+}
+
+func contains(list []string, key string) bool {
+	for _, item := range list {
+		if item == key {
+			return true
+		}
+	}
+	return false
+}
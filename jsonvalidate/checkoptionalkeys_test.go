@@ -0,0 +1,61 @@
+package jsonvalidate
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestJsonRootLevelKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    []string
+		wantErr bool
+	}{
+		{"Flat object", `{"a":1,"b":2}`, []string{"a", "b"}, false},
+		{"Nested values skipped", `{"a":{"x":1},"b":[{"y":2}]}`, []string{"a", "b"}, false},
+		{"Duplicate keys kept", `{"a":1,"a":2}`, []string{"a", "a"}, false},
+		{"Empty object", `{}`, nil, false},
+		{"JSON array", `[1,2]`, nil, true},
+		{"Malformed", `{"a":}`, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := JsonRootLevelKeys([]byte(tt.body))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("JsonRootLevelKeys() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("JsonRootLevelKeys() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckOptionalKeys(t *testing.T) {
+	expected := []string{"totalIncome", "wht", "allowances"}
+	optional := []string{"taxYear"}
+
+	tests := []struct {
+		name    string
+		body    string
+		wantErr bool
+	}{
+		{"Only expected keys", `{"totalIncome":1,"wht":0,"allowances":[]}`, false},
+		{"With optional key", `{"totalIncome":1,"wht":0,"allowances":[],"taxYear":2567}`, false},
+		{"Missing key", `{"totalIncome":1,"wht":0}`, true},
+		{"Unknown key", `{"totalIncome":1,"wht":0,"allowances":[],"year":2567}`, true},
+		{"Duplicate optional key", `{"totalIncome":1,"wht":0,"allowances":[],"taxYear":2567,"taxYear":2566}`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckOptionalKeys([]byte(tt.body), expected, optional)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CheckOptionalKeys() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
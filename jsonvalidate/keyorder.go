@@ -0,0 +1,39 @@
+package jsonvalidate
+
+import (
+	"github.com/windeesel365/assessment-tax/i18n"
+)
+
+// KeyOrder คือโหมดการเช็คลำดับของ root-level keys
+type KeyOrder string
+
+const (
+	// KeyOrderStrict คือ contract เดิม: expectedKeys ต้องมาก่อนตามลำดับ แล้วจึงตามด้วย optional keys
+	KeyOrderStrict KeyOrder = "strict"
+	// KeyOrderAny รับ key ลำดับใดก็ได้ สำหรับ client ที่ JSON library ไม่รับประกันลำดับ
+	KeyOrderAny KeyOrder = "any"
+)
+
+// ParseKeyOrder แปลงค่าจาก config หรือ header เป็น KeyOrder, ค่าว่างคือ KeyOrderStrict
+func ParseKeyOrder(s string) (KeyOrder, error) {
+	switch KeyOrder(s) {
+	case "", KeyOrderStrict:
+		return KeyOrderStrict, nil
+	case KeyOrderAny:
+		return KeyOrderAny, nil
+	}
+	return "", i18n.Errorf("key order must be %s or %s", KeyOrderStrict, KeyOrderAny)
+}
+
+// CheckRootKeys เช็ค root-level keys ตามโหมด order
+// ทั้งสองโหมดต้องมี expectedKeys ครบ และไม่รับ key ที่ไม่รู้จักหรือซ้ำ ต่างกันแค่การเช็คลำดับ
+func CheckRootKeys(body []byte, expectedKeys, optionalKeys []string, order KeyOrder) error {
+	if order == KeyOrderAny {
+		return CheckKeySet(body, expectedKeys, optionalKeys)
+	}
+
+	if err := CheckOptionalKeys(body, expectedKeys, optionalKeys); err != nil {
+		return err
+	}
+	return CheckJSONOrder(body, expectedKeys)
+}
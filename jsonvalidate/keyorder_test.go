@@ -0,0 +1,61 @@
+package jsonvalidate
+
+import "testing"
+
+func TestParseKeyOrder(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    KeyOrder
+		wantErr bool
+	}{
+		{"", KeyOrderStrict, false},
+		{"strict", KeyOrderStrict, false},
+		{"any", KeyOrderAny, false},
+		{"ANY", "", true},
+		{"loose", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseKeyOrder(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseKeyOrder(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseKeyOrder(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckRootKeys(t *testing.T) {
+	expected := []string{"totalIncome", "wht", "allowances"}
+	optional := []string{"taxYear"}
+
+	tests := []struct {
+		name      string
+		body      string
+		strictErr bool
+		anyErr    bool
+	}{
+		{"Expected order", `{"totalIncome":1,"wht":0,"allowances":[],"taxYear":2567}`, false, false},
+		{"Reordered expected keys", `{"wht":0,"allowances":[],"totalIncome":1}`, true, false},
+		{"Optional key first", `{"taxYear":2567,"totalIncome":1,"wht":0,"allowances":[]}`, true, false},
+		{"Missing key", `{"allowances":[],"totalIncome":1}`, true, true},
+		{"Unknown key", `{"year":2567,"totalIncome":1,"wht":0,"allowances":[]}`, true, true},
+		{"Duplicate key", `{"wht":0,"totalIncome":1,"wht":0,"allowances":[]}`, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckRootKeys([]byte(tt.body), expected, optional, KeyOrderStrict)
+			if (err != nil) != tt.strictErr {
+				t.Errorf("CheckRootKeys(strict) error = %v, wantErr %v", err, tt.strictErr)
+			}
+			err = CheckRootKeys([]byte(tt.body), expected, optional, KeyOrderAny)
+			if (err != nil) != tt.anyErr {
+				t.Errorf("CheckRootKeys(any) error = %v, wantErr %v", err, tt.anyErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package jsonvalidate
+
+import "github.com/windeesel365/assessment-tax/i18n"
+
+// คำแปลภาษาไทยของ error ใน package jsonvalidate
+func init() {
+	i18n.Register(i18n.Thai, map[string]string{
+		"unknown key name: %s. Then process again":                              "ไม่รู้จัก key ชื่อ %s กรุณาแก้ไขแล้วส่งใหม่",
+		"input data '%s' more than once, check and fill again":                  "ใส่ข้อมูล '%s' มากกว่าหนึ่งครั้ง กรุณาตรวจสอบแล้วกรอกใหม่",
+		"please enter key(s) %s, missing %s. Then process again":                "กรุณาใส่ key %s โดยยังขาด %s แล้วส่งใหม่",
+		"please enter key(s) %s, optionally followed by %s. Then process again": "กรุณาใส่ key %s และตามด้วย %s ได้ถ้าต้องการ แล้วส่งใหม่",
+		"please enter just %d key(s) ordered by: %s. Then process again":        "กรุณาใส่ key %d ตัวเท่านั้น เรียงตามลำดับ: %s แล้วส่งใหม่",
+		"please enter data in correct order, key name: %s. Then process again":  "กรุณาใส่ข้อมูลตามลำดับที่ถูกต้อง ชื่อ key: %s แล้วส่งใหม่",
+		"key order must be %s or %s":                                            "key order ต้องเป็น %s หรือ %s",
+		"JSON body must be an object":                                           "JSON body ต้องเป็น object",
+		"cannot use %s as %s":                                                   "ใช้ %s เป็น %s ไม่ได้",
+		invalidJSONMessage + "%s":                                               "JSON ไม่ถูกต้อง: %s",
+		"unexpected data after JSON value":                                      "มีข้อมูลเกินมาหลัง JSON value",
+		"duplicate key":                                                         "key ซ้ำ",
+		"unknown field":                                                         "ไม่รู้จัก field นี้",
+		"must be a string":                                                      "ต้องเป็น string",
+		"must be a boolean":                                                     "ต้องเป็น boolean",
+		"must be an integer":                                                    "ต้องเป็นจำนวนเต็ม",
+		"must be a number":                                                      "ต้องเป็นตัวเลข",
+		"must be an array":                                                      "ต้องเป็น array",
+		"must be an object":                                                     "ต้องเป็น object",
+	})
+}
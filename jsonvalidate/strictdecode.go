@@ -0,0 +1,329 @@
+package jsonvalidate
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/windeesel365/assessment-tax/i18n"
+)
+
+// FieldError คือปัญหาหนึ่งจุดใน JSON body
+// Path คือตำแหน่งแบบ allowances[2].amount, ว่างคือทั้ง body
+// Err คือ error ต้นทางของ Message ถ้ามี เช่น error จาก UnmarshalJSON ของ money.Money ใช้แปล Message ตามภาษา
+type FieldError struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+	Err     error  `json:"-"`
+}
+
+// invalidJSONMessage นำหน้า Message ของ FieldError ที่ body ไม่ใช่ JSON ที่ถูกต้อง
+const invalidJSONMessage = "invalid JSON: "
+
+func (e FieldError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+// Syntax เป็น true เมื่อปัญหาคือ body ไม่ใช่ JSON ที่ถูกต้อง ไม่ใช่ค่าของ field ผิด
+func (e FieldError) Syntax() bool {
+	return strings.HasPrefix(e.Message, invalidJSONMessage)
+}
+
+// Localize แสดง Message เป็นภาษา lang
+func (e FieldError) Localize(lang i18n.Lang) string {
+	switch {
+	case e.Err != nil:
+		return i18n.Translate(e.Err, lang)
+	case e.Syntax():
+		// รายละเอียดของ syntax error มาจาก encoding/json จึงแปลเฉพาะข้อความนำหน้า
+		return i18n.Msg(invalidJSONMessage+"%s", strings.TrimPrefix(e.Message, invalidJSONMessage)).In(lang)
+	default:
+		return i18n.Text(e.Message).In(lang)
+	}
+}
+
+// DecodeErrors คือปัญหาทั้งหมดที่ StrictDecode พบ เรียงตามตำแหน่งใน body
+type DecodeErrors []FieldError
+
+func (e DecodeErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, fieldErr := range e {
+		messages = append(messages, fieldErr.Error())
+	}
+	return strings.Join(messages, "; ")
+}
+
+// In แสดงทุกปัญหาเป็นภาษา lang ในรูปแบบเดียวกับ Error
+func (e DecodeErrors) In(lang i18n.Lang) string {
+	messages := make([]string, 0, len(e))
+	for _, fieldErr := range e {
+		message := fieldErr.Localize(lang)
+		if fieldErr.Path != "" {
+			message = fieldErr.Path + ": " + message
+		}
+		messages = append(messages, message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+var (
+	unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	rawMessageType  = reflect.TypeOf(json.RawMessage{})
+)
+
+// StrictDecode อ่าน body ทีละ token ตามโครงสร้างของ v (pointer ไปยัง struct) แล้ว decode เข้า v
+// รายงานทุกปัญหาในครั้งเดียวเป็น DecodeErrors: key ซ้ำทุกระดับ, field ที่ไม่รู้จัก, ชนิดข้อมูลไม่ตรง
+// และข้อมูลที่เกินมาหลัง JSON value, v ไม่ถูกแก้ไขเมื่อมีปัญหา
+// ชื่อ key ต้องตรงกับ json tag ทุกตัวอักษร, null ใช้ได้ทุก field เหมือน encoding/json
+func StrictDecode(body []byte, v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("jsonvalidate: StrictDecode requires a non-nil pointer, got %T", v)
+	}
+
+	d := &strictDecoder{dec: json.NewDecoder(bytes.NewReader(body))}
+	d.dec.UseNumber()
+
+	if err := d.value("", rv.Type().Elem()); err != nil {
+		d.errs = append(d.errs, *err)
+		return d.errs
+	}
+	if _, err := d.dec.Token(); !errors.Is(err, io.EOF) {
+		d.add("", "unexpected data after JSON value")
+	}
+	if len(d.errs) > 0 {
+		return d.errs
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return DecodeErrors{{Message: err.Error(), Err: err}}
+	}
+	return nil
+}
+
+// strictDecoder เก็บ decoder และปัญหาที่พบระหว่างเดิน token
+type strictDecoder struct {
+	dec  *json.Decoder
+	errs DecodeErrors
+}
+
+func (d *strictDecoder) add(path, message string) {
+	d.errs = append(d.errs, FieldError{Path: path, Message: message})
+}
+
+// addErr เพิ่มปัญหาที่มาจาก err โดยเก็บ err ไว้แปลภายหลัง
+func (d *strictDecoder) addErr(path string, err error) {
+	d.errs = append(d.errs, FieldError{Path: path, Message: err.Error(), Err: err})
+}
+
+// value อ่าน JSON value หนึ่งค่าที่ path โดยคาดว่าเป็นชนิด t (nil คือชนิดใดก็ได้)
+// คืน error เฉพาะเมื่อ JSON ผิด syntax ซึ่งอ่านต่อไม่ได้
+func (d *strictDecoder) value(path string, t reflect.Type) *FieldError {
+	token, err := d.dec.Token()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return &FieldError{Path: path, Message: invalidJSONMessage + err.Error()}
+	}
+
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if token == nil || t == rawMessageType {
+		t = nil
+	}
+
+	// type ที่ unmarshal เอง เช่น money.Money และ taxcal.Date ให้ type นั้นตัดสินค่า scalar
+	if t != nil && reflect.PtrTo(t).Implements(unmarshalerType) {
+		if delim, ok := token.(json.Delim); ok {
+			if err := d.composite(delim, path, nil); err != nil {
+				return err
+			}
+			d.addErr(path, i18n.Errorf("cannot use %s as %s", delimKind(delim), t))
+			return nil
+		}
+		raw, _ := json.Marshal(token)
+		if err := reflect.New(t).Interface().(json.Unmarshaler).UnmarshalJSON(raw); err != nil {
+			d.addErr(path, err)
+		}
+		return nil
+	}
+
+	switch token := token.(type) {
+	case json.Delim:
+		return d.composite(token, path, t)
+	case string:
+		if t != nil && t.Kind() != reflect.String && t.Kind() != reflect.Interface {
+			d.typeError(path, t)
+		}
+	case bool:
+		if t != nil && t.Kind() != reflect.Bool && t.Kind() != reflect.Interface {
+			d.typeError(path, t)
+		}
+	case json.Number:
+		d.number(token, path, t)
+	}
+	return nil
+}
+
+// number เช็คว่า JSON number ใส่ใน t ได้ จำนวนเต็มต้องไม่มีทศนิยมและไม่เกินขนาดของ type
+func (d *strictDecoder) number(n json.Number, path string, t reflect.Type) {
+	if t == nil {
+		return
+	}
+	switch t.Kind() {
+	case reflect.Interface, reflect.Float32, reflect.Float64:
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if err := json.Unmarshal([]byte(n), reflect.New(t).Interface()); err != nil {
+			d.typeError(path, t)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if err := json.Unmarshal([]byte(n), reflect.New(t).Interface()); err != nil {
+			d.typeError(path, t)
+		}
+	default:
+		d.typeError(path, t)
+	}
+}
+
+// composite อ่าน object หรือ array ต่อจาก delim เปิด จนถึง delim ปิด
+func (d *strictDecoder) composite(delim json.Delim, path string, t reflect.Type) *FieldError {
+	if delim == '[' {
+		var elem reflect.Type
+		if t != nil {
+			switch t.Kind() {
+			case reflect.Slice, reflect.Array:
+				elem = t.Elem()
+			case reflect.Interface:
+			default:
+				d.typeError(path, t)
+			}
+		}
+		for i := 0; d.dec.More(); i++ {
+			if err := d.value(fmt.Sprintf("%s[%d]", path, i), elem); err != nil {
+				return err
+			}
+		}
+		return d.closing(path)
+	}
+
+	var fields map[string]reflect.Type
+	var elem reflect.Type
+	if t != nil {
+		switch t.Kind() {
+		case reflect.Struct:
+			fields = structFields(t)
+		case reflect.Map:
+			elem = t.Elem()
+		case reflect.Interface:
+		default:
+			d.typeError(path, t)
+		}
+	}
+
+	seen := map[string]bool{}
+	for d.dec.More() {
+		token, err := d.dec.Token()
+		if err != nil {
+			return &FieldError{Path: path, Message: invalidJSONMessage + err.Error()}
+		}
+		key, _ := token.(string)
+		keyPath := joinPath(path, key)
+		if seen[key] {
+			d.add(keyPath, "duplicate key")
+		}
+		seen[key] = true
+
+		child := elem
+		if fields != nil {
+			fieldType, ok := fields[key]
+			if !ok {
+				d.add(keyPath, "unknown field")
+			}
+			child = fieldType
+		}
+		if err := d.value(keyPath, child); err != nil {
+			return err
+		}
+	}
+	return d.closing(path)
+}
+
+// closing อ่าน delim ปิดของ object หรือ array
+func (d *strictDecoder) closing(path string) *FieldError {
+	if _, err := d.dec.Token(); err != nil {
+		return &FieldError{Path: path, Message: invalidJSONMessage + err.Error()}
+	}
+	return nil
+}
+
+func (d *strictDecoder) typeError(path string, t reflect.Type) {
+	d.add(path, "must be "+describeType(t))
+}
+
+// structFields คืน json key ทั้งหมดของ struct และชนิดของแต่ละ field รวม field ของ struct ที่ embed
+func structFields(t reflect.Type) map[string]reflect.Type {
+	fields := map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			for embedded, fieldType := range structFields(field.Type) {
+				fields[embedded] = fieldType
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields[name] = field.Type
+	}
+	return fields
+}
+
+// describeType อธิบายชนิด JSON ที่ t รับ
+func describeType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "an integer"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	default:
+		return "an object"
+	}
+}
+
+func delimKind(delim json.Delim) string {
+	if delim == '[' {
+		return "array"
+	}
+	return "object"
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
@@ -0,0 +1,201 @@
+package jsonvalidate
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/windeesel365/assessment-tax/i18n"
+)
+
+type strictAllowance struct {
+	AllowanceType string  `json:"allowanceType"`
+	Amount        float64 `json:"amount"`
+}
+
+type strictRequest struct {
+	TotalIncome float64           `json:"totalIncome"`
+	Allowances  []strictAllowance `json:"allowances"`
+	TaxYear     int               `json:"taxYear,omitempty"`
+	Spouse      *struct {
+		Name string `json:"name"`
+	} `json:"spouse,omitempty"`
+	Extra json.RawMessage `json:"extra,omitempty"`
+}
+
+func TestStrictDecode(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want DecodeErrors
+	}{
+		{
+			name: "Valid body",
+			body: `{"totalIncome":500000.0,"allowances":[{"allowanceType":"donation","amount":0.0}],"spouse":null}`,
+			want: nil,
+		},
+		{
+			name: "Raw message accepts any value",
+			body: `{"totalIncome":1,"allowances":[],"extra":{"a":[1,"b",true]}}`,
+			want: nil,
+		},
+		{
+			name: "Duplicate key at root",
+			body: `{"totalIncome":1,"totalIncome":2,"allowances":[]}`,
+			want: DecodeErrors{{Path: "totalIncome", Message: "duplicate key"}},
+		},
+		{
+			name: "Duplicate keys nested in array",
+			body: `{"totalIncome":1,"allowances":[{"allowanceType":"donation","amount":1},{"allowanceType":"donation","amount":1,"amount":2}]}`,
+			want: DecodeErrors{{Path: "allowances[1].amount", Message: "duplicate key"}},
+		},
+		{
+			name: "Duplicate key inside raw message",
+			body: `{"totalIncome":1,"allowances":[],"extra":{"a":1,"a":2}}`,
+			want: DecodeErrors{{Path: "extra.a", Message: "duplicate key"}},
+		},
+		{
+			name: "Unknown fields",
+			body: `{"totalIncome":1,"allowances":[{"allowanceType":"donation","amout":1}],"TotalIncome":2}`,
+			want: DecodeErrors{
+				{Path: "allowances[0].amout", Message: "unknown field"},
+				{Path: "TotalIncome", Message: "unknown field"},
+			},
+		},
+		{
+			name: "Wrong types reported together",
+			body: `{"totalIncome":"500000","allowances":[{"allowanceType":1,"amount":[]}],"taxYear":2567.5,"spouse":{"name":true}}`,
+			want: DecodeErrors{
+				{Path: "totalIncome", Message: "must be a number"},
+				{Path: "allowances[0].allowanceType", Message: "must be a string"},
+				{Path: "allowances[0].amount", Message: "must be a number"},
+				{Path: "taxYear", Message: "must be an integer"},
+				{Path: "spouse.name", Message: "must be a string"},
+			},
+		},
+		{
+			name: "Object where array expected",
+			body: `{"totalIncome":1,"allowances":{"allowanceType":"donation"}}`,
+			want: DecodeErrors{{Path: "allowances", Message: "must be an array"}},
+		},
+		{
+			name: "Trailing data",
+			body: `{"totalIncome":1,"allowances":[]} {"totalIncome":2}`,
+			want: DecodeErrors{{Message: "unexpected data after JSON value"}},
+		},
+		{
+			name: "Syntax error keeps earlier problems",
+			body: `{"totalIncome":1,"totalIncome":2,"allowances":[}`,
+			want: DecodeErrors{
+				{Path: "totalIncome", Message: "duplicate key"},
+				{Path: "allowances", Message: "invalid JSON: invalid character '}' looking for beginning of value"},
+			},
+		},
+		{
+			name: "Truncated body",
+			body: `{"totalIncome":1,"allowances":`,
+			want: DecodeErrors{{Path: "allowances", Message: "invalid JSON: unexpected EOF"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var req strictRequest
+			err := StrictDecode([]byte(tt.body), &req)
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("StrictDecode() error = %v", err)
+				}
+				return
+			}
+			var got DecodeErrors
+			if !errors.As(err, &got) {
+				t.Fatalf("StrictDecode() error = %v, want DecodeErrors", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("StrictDecode() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStrictDecodeValue(t *testing.T) {
+	var req strictRequest
+	body := `{"totalIncome":500000.0,"allowances":[{"allowanceType":"donation","amount":200.0}],"taxYear":2566}`
+	if err := StrictDecode([]byte(body), &req); err != nil {
+		t.Fatalf("StrictDecode() error = %v", err)
+	}
+	want := strictRequest{TotalIncome: 500000, Allowances: []strictAllowance{{"donation", 200}}, TaxYear: 2566}
+	if !reflect.DeepEqual(req, want) {
+		t.Errorf("StrictDecode() = %+v, want %+v", req, want)
+	}
+
+	if err := StrictDecode([]byte(body), req); err == nil {
+		t.Error("StrictDecode() with non-pointer error = nil, want error")
+	}
+}
+
+func TestDecodeErrorsError(t *testing.T) {
+	err := DecodeErrors{{Path: "allowances[0].amount", Message: "must be a number"}, {Message: "unexpected data after JSON value"}}
+	if want := "allowances[0].amount: must be a number; unexpected data after JSON value"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestFieldErrorSyntax(t *testing.T) {
+	err := StrictDecode([]byte(`{"totalIncome":1,"allowances":[}`), &strictRequest{})
+	var decodeErrs DecodeErrors
+	if !errors.As(err, &decodeErrs) || len(decodeErrs) != 1 || !decodeErrs[0].Syntax() {
+		t.Fatalf("StrictDecode() error = %#v, want one syntax error", err)
+	}
+
+	err = StrictDecode([]byte(`{"totalIncome":"x"}`), &strictRequest{})
+	if !errors.As(err, &decodeErrs) || len(decodeErrs) != 1 || decodeErrs[0].Syntax() {
+		t.Errorf("StrictDecode() error = %#v, want one non-syntax error", err)
+	}
+}
+
+func TestDecodeErrorsIn(t *testing.T) {
+	var req strictRequest
+	err := StrictDecode([]byte(`{"totalIncome":"1","allowances":[],"taxYear":2566,"taxYear":2567,"extra":{"a":1}}`+`x`), &req)
+	var got DecodeErrors
+	if !errors.As(err, &got) {
+		t.Fatalf("StrictDecode() error = %v, want DecodeErrors", err)
+	}
+
+	tests := []struct {
+		lang i18n.Lang
+		want string
+	}{
+		{"", got.Error()},
+		{i18n.English, got.Error()},
+		{i18n.Thai, "totalIncome: ต้องเป็นตัวเลข; taxYear: key ซ้ำ; มีข้อมูลเกินมาหลัง JSON value"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.lang), func(t *testing.T) {
+			if message := got.In(tt.lang); message != tt.want {
+				t.Errorf("In(%q) = %q, want %q", tt.lang, message, tt.want)
+			}
+		})
+	}
+}
+
+func TestFieldErrorLocalize(t *testing.T) {
+	tests := []struct {
+		name     string
+		fieldErr FieldError
+		want     string
+	}{
+		{"err", FieldError{Message: "cannot use array as string", Err: i18n.Errorf("cannot use %s as %s", "array", "string")}, "ใช้ array เป็น string ไม่ได้"},
+		{"syntax", FieldError{Message: "invalid JSON: unexpected EOF"}, "JSON ไม่ถูกต้อง: unexpected EOF"},
+		{"untranslated", FieldError{Message: "something else"}, "something else"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.fieldErr.Localize(i18n.Thai); got != tt.want {
+				t.Errorf("Localize() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -11,14 +11,18 @@ import (
 	"os"
 	"os/signal"
 	"regexp"
+	"strconv"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/joho/godotenv"
 	_ "github.com/lib/pq"
-	"github.com/shopspring/decimal"
+	"github.com/windeesel365/assessment-tax/apierror"
+	"github.com/windeesel365/assessment-tax/fxrates"
 	"github.com/windeesel365/assessment-tax/handlefileupload"
 	"github.com/windeesel365/assessment-tax/handletax"
+	"github.com/windeesel365/assessment-tax/jsonvalidate"
+	"github.com/windeesel365/assessment-tax/money"
 	"github.com/windeesel365/assessment-tax/pgdb"
 	"github.com/windeesel365/assessment-tax/sharedvars"
 	"github.com/windeesel365/assessment-tax/validityguard"
@@ -29,22 +33,7 @@ import (
 
 // pattern ที่ admin input request
 type Deduction struct {
-	Amount float64 `json:"amount"`
-}
-
-// CustomFloat64 เป็น float64 ที่ custom ใหม่
-type CustomFloat64 float64
-
-// customizes ตัวเลขการเงิน เพื่อ output decimal places ตามต้องการ
-func (cf CustomFloat64) MarshalJSON() ([]byte, error) {
-	d := decimal.NewFromFloat(float64(cf))     //จาก github.com/shopspring/decimal
-	formatted := d.RoundBank(1).StringFixed(1) // RoundBank เพื่อ banker's rounding // StringFixed ตำแหน่งทศนิยมในข้อมูลที่จะแสดงผล
-	return []byte(formatted), nil
-}
-
-type TaxLevel struct {
-	Level string        `json:"level"`
-	Tax   CustomFloat64 `json:"tax"`
+	Amount money.Money `json:"amount"`
 }
 
 type jwtCustomClaims struct {
@@ -53,22 +42,20 @@ type jwtCustomClaims struct {
 	jwt.RegisteredClaims
 }
 
-type PersonalDeduction struct {
-	ID                int
-	PersonalDeduction float64
-}
-
-type KReceiptDeduction struct {
-	ID                        int
-	UpperLimKReceiptDeduction float64
-}
-
 func main() {
 
 	e := echo.New()
+	e.Use(middleware.RequestID())
 	e.Use(middleware.Logger())
 	e.Use(middleware.Recover())
 
+	// error ทุก endpoint ส่งเป็น application/problem+json พร้อม code และ requestId
+	e.HTTPErrorHandler = handletax.HTTPErrorHandler
+
+	// ภาษาของ error และ label ขั้นบันใดภาษี: ?lang=th|en หรือ header Accept-Language
+	// ไม่ระบุคือข้อความเดิม (error ภาษาอังกฤษ, label ภาษาไทย)
+	e.Use(handletax.Language())
+
 	// load environment variables from .env file
 	if err := godotenv.Load(); err != nil {
 		log.Fatalf("Error loading .env file: %v", err)
@@ -86,6 +73,29 @@ func main() {
 		log.Fatal("before starting server, please ensure that PORT environment variable must in 4-digit number.")
 	}
 
+	// รูปแบบจำนวนเงินของทุก response: MONEY_ROUNDING เช่น half-up:2 และ
+	// MONEY_ROUNDING_FIELDS เช่น tax=down:0,taxRefund=down:0, request ทับได้ด้วย ?rounding=
+	roundingPolicy, err := loadRoundingPolicy()
+	if err != nil {
+		log.Fatalf("invalid rounding environment variable: %v", err)
+	}
+	e.JSONSerializer = handletax.JSONSerializer{}
+	e.Use(handletax.RoundingPolicy(roundingPolicy))
+
+	// ลำดับ key ของ JSON body: JSON_KEY_ORDER=strict (ค่าเริ่มต้น, contract เดิม) หรือ any
+	// request ทับได้ด้วย header X-JSON-Key-Order
+	keyOrder, err := jsonvalidate.ParseKeyOrder(os.Getenv("JSON_KEY_ORDER"))
+	if err != nil {
+		log.Fatalf("invalid JSON_KEY_ORDER environment variable: %v", err)
+	}
+	e.Use(handletax.JSONKeyOrder(keyOrder))
+
+	// จำนวนรายการสูงสุดต่อ batch ของ tax/calculations/batch: TAX_BATCH_LIMIT
+	batchLimit, err := loadBatchLimit()
+	if err != nil {
+		log.Fatalf("invalid TAX_BATCH_LIMIT environment variable: %v", err)
+	}
+
 	// Postgresql preparation part
 	// Retrieve DATABASE_URL from environment
 	databaseURL := os.Getenv("DATABASE_URL")
@@ -95,7 +105,6 @@ func main() {
 	}
 
 	// สร้าง connection กับ postgresql
-	var err error
 	sharedvars.Db, err = sql.Open("postgres", databaseURL)
 	if err != nil {
 		log.Fatal(err)
@@ -129,7 +138,7 @@ func main() {
 		if err != nil {
 			log.Fatal(err)
 		}
-		fmt.Printf("PostgreSQL: Created admin deductions row with id: %d, initialPersonalExemption: %f\n kReceiptsUpperLimit: %f\n", id, sharedvars.InitialPersonalExemption, sharedvars.KReceiptsUpperLimit)
+		fmt.Printf("PostgreSQL: Created admin deductions row with id: %d, initialPersonalExemption: %s\n kReceiptsUpperLimit: %s\n", id, sharedvars.InitialPersonalExemption, sharedvars.KReceiptsUpperLimit)
 	} //else ไปเช็คค่าใน db
 
 	// ใช้ row แรกสุด คือ id แรกสุด ในการ update และ read
@@ -140,6 +149,18 @@ func main() {
 
 	sharedvars.Id = lowestID // id เป็น id สำหรับ ref ข้อมูล postgresql
 
+	// create 'exchange_rates' table แล้วโหลดอัตราแลกเปลี่ยนที่ admin กำหนดไว้เข้า memory
+	err = pgdb.CreateExchangeRatesTable(sharedvars.Db)
+	if err != nil {
+		log.Fatal(err)
+	}
+	rates, err := pgdb.GetExchangeRates(sharedvars.Db)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fxrates.Load(rates)
+	fmt.Printf("PostgreSQL: Loaded %d exchange rates.\n", len(rates))
+
 	//เช็ค authentication ของ admin
 	adminUsername := os.Getenv("ADMIN_USERNAME")
 	adminPassword := os.Getenv("ADMIN_PASSWORD")
@@ -157,6 +178,12 @@ func main() {
 
 	e.POST("/tax/calculations", handletax.HandleTaxCalculation)
 	e.POST("/tax/calculations/upload-csv", handlefileupload.HandleFileUpload)
+	e.POST("/tax/calculations/batch", handletax.HandleTaxBatchCalculation(batchLimit))
+	e.POST("/tax/reverse", handletax.HandleReverseCalculation)
+	e.POST("/tax/advice", handletax.HandleTaxAdvice)
+	e.POST("/tax/payroll", handletax.HandlePayrollWithholding)
+	e.POST("/tax/severance", handletax.HandleSeveranceCalculation)
+	e.POST("/tax/compare", handletax.HandleTaxComparison)
 
 	adminGroup := e.Group("/admin")
 	adminGroup.Use(basicAuthMiddleware)
@@ -166,6 +193,9 @@ func main() {
 	adminGroup.POST("/deductions/personal", setPersonalDeduction)
 	adminGroup.POST("/deductions/k-receipt", setKReceiptDeduction)
 
+	adminGroup.GET("/exchange-rates", getExchangeRates)
+	adminGroup.POST("/exchange-rates", setExchangeRate)
+
 	//graceful shutdown //start server in goroutine
 	go func() {
 		if err := e.Start(":" + port); err != nil && err != http.ErrServerClosed {
@@ -224,7 +254,7 @@ func setPersonalDeduction(c echo.Context) error {
 	// read Body ให้เป็น variable
 	body, err := ioutil.ReadAll(c.Request().Body)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "Invalid input")
+		return apierror.BadRequest(apierror.CodeInvalidBody, "Invalid input")
 	}
 	defer c.Request().Body.Close()
 
@@ -237,7 +267,7 @@ func setPersonalDeduction(c echo.Context) error {
 	// bind JSON to struct
 	d := new(Deduction)
 	if err := json.Unmarshal(body, d); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "Invalid input format: "+err.Error())
+		return apierror.Decode(err)
 	}
 
 	// set change to initialPersonalExemption
@@ -246,17 +276,17 @@ func setPersonalDeduction(c echo.Context) error {
 	// update PersonalDeduction to postgres db
 	err = pgdb.UpdatePersonalDeduction(sharedvars.Db, sharedvars.Id, sharedvars.InitialPersonalExemption)
 	if err != nil {
-		log.Fatal(err)
+		return apierror.Internal("Could not save personal deduction, please try again", err)
 	}
 	// read หลังการ update ทำ log ในระบบ
 	adminPDeductions, err := pgdb.GetPersonalDeduction(sharedvars.Db, sharedvars.Id)
 	if err != nil {
-		log.Fatal(err)
+		return apierror.Internal("Could not read personal deduction, please try again", err)
 	}
 	fmt.Printf("***********\nAdmin updated initialPersonalExemption validated then updated postgresql row: %+v\n", adminPDeductions)
 
 	//respond client(admin)
-	return c.JSON(http.StatusOK, map[string]CustomFloat64{"personalDeduction": CustomFloat64(d.Amount)})
+	return c.JSON(http.StatusOK, map[string]money.Money{"personalDeduction": d.Amount})
 }
 
 // POST: /admin/deductions/k-receipt
@@ -264,7 +294,7 @@ func setKReceiptDeduction(c echo.Context) error {
 	// Read body to a variable
 	body, err := ioutil.ReadAll(c.Request().Body)
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "Invalid input")
+		return apierror.BadRequest(apierror.CodeInvalidBody, "Invalid input")
 	}
 	defer c.Request().Body.Close()
 
@@ -277,7 +307,7 @@ func setKReceiptDeduction(c echo.Context) error {
 	// Bind JSON to struct
 	d := new(Deduction)
 	if err := json.Unmarshal(body, d); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "Invalid input format: "+err.Error())
+		return apierror.Decode(err)
 	}
 
 	// Set change to kReceiptsUpperLimit
@@ -287,18 +317,90 @@ func setKReceiptDeduction(c echo.Context) error {
 	// update KReceiptDeduction to postgres db
 	err = pgdb.UpdateKReceiptDeduction(sharedvars.Db, sharedvars.Id, sharedvars.KReceiptsUpperLimit)
 	if err != nil {
-		log.Fatal(err)
+		return apierror.Internal("Could not save k-receipt deduction, please try again", err)
 	}
 	// read หลังการ update ทำ log ในระบบ
 	adminKDeductions, err := pgdb.GetKReceiptDeduction(sharedvars.Db, sharedvars.Id)
 	if err != nil {
-		log.Fatal(err)
+		return apierror.Internal("Could not read k-receipt deduction, please try again", err)
 	}
 
 	fmt.Printf("***********\nAdmin updated kReceiptsUpperLimit validated then updated postgresql row: %+v\n", adminKDeductions)
 
 	//respond to client(admin)
-	return c.JSON(http.StatusOK, map[string]CustomFloat64{
-		"kReceipt": CustomFloat64(d.Amount)})
+	return c.JSON(http.StatusOK, map[string]money.Money{
+		"kReceipt": d.Amount})
+
+}
 
+// GET: /admin/exchange-rates
+func getExchangeRates(c echo.Context) error {
+	return c.JSON(http.StatusOK, map[string][]fxrates.Rate{"exchangeRates": fxrates.All()})
+}
+
+// POST: /admin/exchange-rates
+func setExchangeRate(c echo.Context) error {
+	// Read body to a variable
+	body, err := ioutil.ReadAll(c.Request().Body)
+	if err != nil {
+		return apierror.BadRequest(apierror.CodeInvalidBody, "Invalid input")
+	}
+	defer c.Request().Body.Close()
+
+	// validation function process
+	if err := validityguard.ValidateExchangeRateInput(body); err != nil {
+		return err
+	}
+
+	// หลังจากการ validation
+	// bind JSON to struct
+	in := validityguard.ExchangeRateInput{}
+	if err := json.Unmarshal(body, &in); err != nil {
+		return apierror.Decode(err)
+	}
+	rate := in.ToRate()
+
+	// บันทึกลง postgres db ก่อน แล้วค่อย update ตารางใน memory
+	if err := pgdb.SaveExchangeRate(sharedvars.Db, rate); err != nil {
+		return apierror.Internal("Could not save exchange rate, please try again", err)
+	}
+	fxrates.Set(rate)
+
+	fmt.Printf("***********\nAdmin updated exchange rate validated then updated postgresql: %+v\n", in)
+
+	//respond client(admin)
+	return c.JSON(http.StatusOK, rate)
+}
+
+// loadRoundingPolicy อ่านรูปแบบจำนวนเงินจาก environment, ไม่กำหนดคือ money.DefaultPolicy
+func loadRoundingPolicy() (money.Policy, error) {
+	policy := money.DefaultPolicy()
+
+	var defaultFormat *money.Format
+	if spec := os.Getenv("MONEY_ROUNDING"); spec != "" {
+		f, err := money.ParseFormat(spec)
+		if err != nil {
+			return money.Policy{}, err
+		}
+		defaultFormat = &f
+	}
+	fields, err := money.ParseFieldFormats(os.Getenv("MONEY_ROUNDING_FIELDS"))
+	if err != nil {
+		return money.Policy{}, err
+	}
+
+	return policy.Override(defaultFormat, fields), nil
+}
+
+// loadBatchLimit อ่านจำนวนรายการสูงสุดต่อ batch จาก environment, ไม่กำหนดคือ validityguard.DefaultBatchLimit
+func loadBatchLimit() (int, error) {
+	value := os.Getenv("TAX_BATCH_LIMIT")
+	if value == "" {
+		return validityguard.DefaultBatchLimit, nil
+	}
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 1 {
+		return 0, fmt.Errorf("must be a positive integer, got %q", value)
+	}
+	return limit, nil
 }
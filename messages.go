@@ -0,0 +1,15 @@
+package main
+
+import "github.com/windeesel365/assessment-tax/i18n"
+
+// คำแปลภาษาไทยของ error ของ admin endpoint
+func init() {
+	i18n.Register(i18n.Thai, map[string]string{
+		"There was a problem logging in. Check your username and password.": "เข้าสู่ระบบไม่สำเร็จ กรุณาตรวจสอบ username และ password",
+		"Could not save personal deduction, please try again":               "บันทึกค่าลดหย่อนส่วนตัวไม่สำเร็จ กรุณาลองใหม่",
+		"Could not read personal deduction, please try again":               "อ่านค่าลดหย่อนส่วนตัวไม่สำเร็จ กรุณาลองใหม่",
+		"Could not save k-receipt deduction, please try again":              "บันทึกค่าลดหย่อน k-receipt ไม่สำเร็จ กรุณาลองใหม่",
+		"Could not read k-receipt deduction, please try again":              "อ่านค่าลดหย่อน k-receipt ไม่สำเร็จ กรุณาลองใหม่",
+		"Could not save exchange rate, please try again":                    "บันทึกอัตราแลกเปลี่ยนไม่สำเร็จ กรุณาลองใหม่",
+	})
+}
@@ -0,0 +1,16 @@
+package money
+
+import "github.com/windeesel365/assessment-tax/i18n"
+
+// คำแปลภาษาไทยของ error ใน package money
+func init() {
+	i18n.Register(i18n.Thai, map[string]string{
+		"invalid money amount %q":                                     "จำนวนเงิน %q ไม่ถูกต้อง",
+		"cannot unmarshal string %s into money amount":                "แปลงข้อความ %s เป็นจำนวนเงินไม่ได้",
+		"cannot unmarshal %s into money amount":                       "แปลง %s เป็นจำนวนเงินไม่ได้",
+		"rounding %q must be in the form mode:places, e.g. half-up:2": "rounding %q ต้องอยู่ในรูปแบบ mode:places เช่น half-up:2",
+		"rounding mode %q must be one of: %s, %s, %s":                 "rounding mode %q ต้องเป็นหนึ่งใน: %s, %s, %s",
+		"rounding places %q must be between 0 and %d":                 "rounding places %q ต้องอยู่ระหว่าง 0 ถึง %d",
+		"field rounding %q must be in the form field=mode:places":     "field rounding %q ต้องอยู่ในรูปแบบ field=mode:places",
+	})
+}
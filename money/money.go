@@ -0,0 +1,141 @@
+// Package money เป็น type จำนวนเงินแบบ decimal ที่ใช้ร่วมกันทั้ง pipeline
+// เพื่อให้ผลลัพธ์แม่นยำถึงสตางค์ ไม่มี error จาก float64
+package money
+
+import (
+	"bytes"
+	"database/sql/driver"
+
+	"github.com/shopspring/decimal"
+	"github.com/windeesel365/assessment-tax/i18n"
+)
+
+// Money คือจำนวนเงินบาทแบบ decimal, zero value คือ 0 บาท
+// format ถูกกำหนดโดย Policy.Apply ตอนแสดงผล (nil คือ DefaultFormat)
+type Money struct {
+	d      decimal.Decimal
+	format *Format
+}
+
+// Zero คือ 0 บาท
+var Zero = Money{}
+
+// New สร้าง Money จาก float64 (ใช้กับค่าคงที่ในโค้ด)
+func New(f float64) Money {
+	return Money{d: decimal.NewFromFloat(f)}
+}
+
+// NewFromInt สร้าง Money จากจำนวนเต็มบาท
+func NewFromInt(i int64) Money {
+	return Money{d: decimal.NewFromInt(i)}
+}
+
+// NewFromDecimal สร้าง Money จาก decimal.Decimal
+func NewFromDecimal(d decimal.Decimal) Money {
+	return Money{d: d}
+}
+
+// Parse แปลง string ตัวเลข เช่นจาก csv เป็น Money
+func Parse(s string) (Money, error) {
+	d, err := decimal.NewFromString(s)
+	if err != nil {
+		return Zero, i18n.Errorf("invalid money amount %q", s)
+	}
+	return Money{d: d}, nil
+}
+
+// Rate แปลงอัตรา เช่น 0.1 เป็น decimal.Decimal สำหรับใช้กับ Mul
+func Rate(f float64) decimal.Decimal {
+	return decimal.NewFromFloat(f)
+}
+
+func (m Money) Add(o Money) Money { return Money{d: m.d.Add(o.d)} }
+func (m Money) Sub(o Money) Money { return Money{d: m.d.Sub(o.d)} }
+func (m Money) Neg() Money        { return Money{d: m.d.Neg()} }
+
+// Mul คูณจำนวนเงินด้วยอัตรา เช่น อัตราภาษี
+func (m Money) Mul(rate decimal.Decimal) Money { return Money{d: m.d.Mul(rate)} }
+
+// MulInt คูณจำนวนเงินด้วยจำนวนนับ เช่น จำนวนบุตร
+func (m Money) MulInt(n int64) Money { return Money{d: m.d.Mul(decimal.NewFromInt(n))} }
+
+// DivInt หารจำนวนเงินด้วยจำนวนนับ ผลลัพธ์ยังไม่ปัดเศษถึงสตางค์
+func (m Money) DivInt(n int64) Money { return Money{d: m.d.Div(decimal.NewFromInt(n))} }
+
+// Round ปัดเศษ half away from zero ตามจำนวนตำแหน่งทศนิยม
+func (m Money) Round(places int32) Money { return Money{d: m.d.Round(places)} }
+
+func (m Money) Cmp(o Money) int                 { return m.d.Cmp(o.d) }
+func (m Money) Equal(o Money) bool              { return m.d.Equal(o.d) }
+func (m Money) GreaterThan(o Money) bool        { return m.d.GreaterThan(o.d) }
+func (m Money) GreaterThanOrEqual(o Money) bool { return m.d.GreaterThanOrEqual(o.d) }
+func (m Money) LessThan(o Money) bool           { return m.d.LessThan(o.d) }
+func (m Money) LessThanOrEqual(o Money) bool    { return m.d.LessThanOrEqual(o.d) }
+func (m Money) IsZero() bool                    { return m.d.IsZero() }
+func (m Money) IsNegative() bool                { return m.d.IsNegative() }
+func (m Money) IsPositive() bool                { return m.d.IsPositive() }
+func (m Money) Decimal() decimal.Decimal        { return m.d }
+func (m Money) IntPart() int64                  { return m.d.IntPart() }
+func (m Money) String() string                  { return m.d.String() }
+func (m Money) StringFixed(places int32) string { return m.d.StringFixed(places) }
+
+// Min คืนค่าที่น้อยกว่า
+func Min(a, b Money) Money {
+	if a.LessThan(b) {
+		return a
+	}
+	return b
+}
+
+// Max คืนค่าที่มากกว่า
+func Max(a, b Money) Money {
+	if a.GreaterThan(b) {
+		return a
+	}
+	return b
+}
+
+// Sum รวมจำนวนเงินทั้งหมด
+func Sum(amounts ...Money) Money {
+	total := Zero
+	for _, amount := range amounts {
+		total = total.Add(amount)
+	}
+	return total
+}
+
+// customizes ตัวเลขการเงิน เพื่อ output decimal places ตาม Format ที่ Policy กำหนด
+func (m Money) MarshalJSON() ([]byte, error) {
+	f := DefaultFormat
+	if m.format != nil {
+		f = *m.format
+	}
+	formatted := f.round(m.d).StringFixed(f.Places) // StringFixed ตำแหน่งทศนิยมในข้อมูลที่จะแสดงผล
+	return []byte(formatted), nil
+}
+
+// UnmarshalJSON รับเฉพาะ JSON number เหมือน float64 เดิม (ไม่รับ string)
+func (m *Money) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '"' {
+		return i18n.Errorf("cannot unmarshal string %s into money amount", data)
+	}
+	d, err := decimal.NewFromString(string(data))
+	if err != nil {
+		return i18n.Errorf("cannot unmarshal %s into money amount", data)
+	}
+	m.d = d
+	return nil
+}
+
+// Value ให้ database/sql เขียน Money ลง postgresql
+func (m Money) Value() (driver.Value, error) {
+	return m.d.Value()
+}
+
+// Scan ให้ database/sql อ่าน Money จาก postgresql
+func (m *Money) Scan(value interface{}) error {
+	return m.d.Scan(value)
+}
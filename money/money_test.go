@@ -0,0 +1,88 @@
+package money
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   Money
+		expected string
+	}{
+		{"Whole baht", NewFromInt(29000), "29000.0"},
+		{"Banker's rounding down", New(0.25), "0.2"},
+		{"Banker's rounding up", New(0.35), "0.4"},
+		{"Zero value", Money{}, "0.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.amount)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.expected {
+				t.Errorf("MarshalJSON() = %s, want %s", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected Money
+		wantErr  bool
+	}{
+		{"Integer", `500000`, NewFromInt(500000), false},
+		{"Satang exact", `0.1`, New(0.1), false},
+		{"Exponent", `1e3`, NewFromInt(1000), false},
+		{"String rejected", `"500000"`, Zero, true},
+		{"Boolean rejected", `true`, Zero, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var m Money
+			err := json.Unmarshal([]byte(tt.input), &m)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UnmarshalJSON(%s) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && !m.Equal(tt.expected) {
+				t.Errorf("UnmarshalJSON(%s) = %v, want %v", tt.input, m, tt.expected)
+			}
+		})
+	}
+}
+
+func TestArithmeticIsExact(t *testing.T) {
+	// 0.1 + 0.2 ต้องเท่ากับ 0.3 พอดี ต่างจาก float64
+	if got := New(0.1).Add(New(0.2)); !got.Equal(New(0.3)) {
+		t.Errorf("0.1 + 0.2 = %v, want 0.3", got)
+	}
+	if got := NewFromInt(350001).Mul(Rate(0.15)); !got.Equal(New(52500.15)) {
+		t.Errorf("350001 * 0.15 = %v, want 52500.15", got)
+	}
+}
+
+func TestMinMaxSum(t *testing.T) {
+	a, b := NewFromInt(100), NewFromInt(200)
+	if !Min(a, b).Equal(a) || !Max(a, b).Equal(b) {
+		t.Errorf("Min/Max(%v, %v) incorrect", a, b)
+	}
+	if got := Sum(a, b, a); !got.Equal(NewFromInt(400)) {
+		t.Errorf("Sum() = %v, want 400", got)
+	}
+}
+
+func TestMulDivInt(t *testing.T) {
+	if got := NewFromInt(30000).MulInt(3); !got.Equal(NewFromInt(90000)) {
+		t.Errorf("30000 * 3 = %v, want 90000", got)
+	}
+	if got := NewFromInt(10000).DivInt(3).Round(2); !got.Equal(New(3333.33)) {
+		t.Errorf("10000 / 3 = %v, want 3333.33", got)
+	}
+}
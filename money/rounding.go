@@ -0,0 +1,213 @@
+package money
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	"github.com/windeesel365/assessment-tax/i18n"
+)
+
+// RoundingMode คือวิธีปัดเศษจำนวนเงินตอนแสดงผลเป็น JSON
+type RoundingMode string
+
+const (
+	RoundHalfEven RoundingMode = "half-even" // banker's rounding (ค่าเริ่มต้นเดิม)
+	RoundHalfUp   RoundingMode = "half-up"   // ครึ่งหนึ่งปัดออกจาก 0 เช่น ledger 2 ตำแหน่ง
+	RoundDown     RoundingMode = "down"      // ตัดเศษทิ้งเข้าหา 0 เช่น บาทเต็มในแบบ ภ.ง.ด.
+)
+
+// MaxPlaces จำนวนตำแหน่งทศนิยมสูงสุดที่แสดงได้
+const MaxPlaces = 4
+
+// Format คือวิธีปัดเศษและจำนวนตำแหน่งทศนิยมของจำนวนเงินหนึ่งค่า
+type Format struct {
+	Mode   RoundingMode
+	Places int32
+}
+
+// DefaultFormat คือรูปแบบเดิม: banker's rounding 1 ตำแหน่ง
+var DefaultFormat = Format{Mode: RoundHalfEven, Places: 1}
+
+// ParseFormat แปลง spec รูปแบบ "mode:places" เช่น "half-up:2" หรือ "down:0"
+func ParseFormat(spec string) (Format, error) {
+	mode, places, ok := strings.Cut(spec, ":")
+	if !ok {
+		return Format{}, i18n.Errorf("rounding %q must be in the form mode:places, e.g. half-up:2", spec)
+	}
+	f := Format{Mode: RoundingMode(mode)}
+	switch f.Mode {
+	case RoundHalfEven, RoundHalfUp, RoundDown:
+	default:
+		return Format{}, i18n.Errorf("rounding mode %q must be one of: %s, %s, %s", mode, RoundHalfEven, RoundHalfUp, RoundDown)
+	}
+	n, err := strconv.Atoi(places)
+	if err != nil || n < 0 || n > MaxPlaces {
+		return Format{}, i18n.Errorf("rounding places %q must be between 0 and %d", places, MaxPlaces)
+	}
+	f.Places = int32(n)
+	return f, nil
+}
+
+// String คืน spec รูปแบบเดียวกับ ParseFormat
+func (f Format) String() string {
+	return fmt.Sprintf("%s:%d", f.Mode, f.Places)
+}
+
+// round ปัดเศษ d ตาม Mode
+func (f Format) round(d decimal.Decimal) decimal.Decimal {
+	switch f.Mode {
+	case RoundHalfUp:
+		return d.Round(f.Places)
+	case RoundDown:
+		return d.Truncate(f.Places)
+	default:
+		return d.RoundBank(f.Places)
+	}
+}
+
+// Policy คือรูปแบบจำนวนเงินของ response ทั้งหมด
+// Fields กำหนดรูปแบบตามชื่อ key ใน JSON ซึ่งมีผลกับจำนวนเงินทั้งหมดภายใต้ key นั้น (key ที่ใกล้ที่สุดชนะ)
+type Policy struct {
+	Default Format
+	Fields  map[string]Format
+}
+
+// DefaultPolicy คือรูปแบบเริ่มต้นของ server
+// installmentPlan แสดงเป็นสตางค์ เพื่อให้ยอดรวมของทุกงวดเท่ากับภาษีที่ต้องชำระ
+func DefaultPolicy() Policy {
+	return Policy{
+		Default: DefaultFormat,
+		Fields:  map[string]Format{"installmentPlan": {Mode: RoundHalfEven, Places: 2}},
+	}
+}
+
+// ParseFieldFormats แปลง spec รูปแบบ "field=mode:places,field=mode:places"
+func ParseFieldFormats(spec string) (map[string]Format, error) {
+	fields := map[string]Format{}
+	if spec == "" {
+		return fields, nil
+	}
+	for _, item := range strings.Split(spec, ",") {
+		field, formatSpec, ok := strings.Cut(strings.TrimSpace(item), "=")
+		if !ok || field == "" {
+			return nil, i18n.Errorf("field rounding %q must be in the form field=mode:places", item)
+		}
+		f, err := ParseFormat(formatSpec)
+		if err != nil {
+			return nil, err
+		}
+		fields[field] = f
+	}
+	return fields, nil
+}
+
+// Override คืน Policy ใหม่ที่ defaultFormat (ถ้าไม่เป็น nil) แทนที่ Default และ fields ทับของเดิมราย key
+// Fields เดิมที่ไม่ได้ระบุใน fields ยังใช้อยู่ เช่น installmentPlan แสดงเป็นสตางค์เพื่อให้ยอดรวมของทุกงวดเท่ากับภาษี
+func (p Policy) Override(defaultFormat *Format, fields map[string]Format) Policy {
+	merged := Policy{Default: p.Default, Fields: map[string]Format{}}
+	if defaultFormat != nil {
+		merged.Default = *defaultFormat
+	}
+	for field, f := range p.Fields {
+		merged.Fields[field] = f
+	}
+	for field, f := range fields {
+		merged.Fields[field] = f
+	}
+	return merged
+}
+
+// Marshal แปลง v เป็น JSON โดยจำนวนเงินทุกค่าใช้รูปแบบตาม Policy
+func (p Policy) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(p.Apply(v))
+}
+
+// Apply คืนสำเนาของ v ที่ Money ทุกค่าถูกกำหนดรูปแบบตาม Policy แล้ว สำหรับส่งต่อให้ json.Marshal
+// v เดิมไม่ถูกแก้ไข
+func (p Policy) Apply(v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	return p.apply(reflect.ValueOf(v), p.Default).Interface()
+}
+
+var moneyType = reflect.TypeOf(Money{})
+
+// apply เดินทุก field/element ของ v แล้วคืนค่าใหม่ชนิดเดียวกัน, f คือรูปแบบของ key ที่ใกล้ที่สุด
+func (p Policy) apply(v reflect.Value, f Format) reflect.Value {
+	if v.Type() == moneyType {
+		m := v.Interface().(Money)
+		formatted := Money{d: f.round(m.d), format: &f}
+		return reflect.ValueOf(formatted)
+	}
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		elem := p.apply(v.Elem(), f)
+		ptr := reflect.New(elem.Type())
+		ptr.Elem().Set(elem)
+		return ptr
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(v.Type()).Elem()
+		out.Set(p.apply(v.Elem(), f))
+		return out
+	case reflect.Struct:
+		out := reflect.New(v.Type()).Elem()
+		out.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !out.Field(i).CanSet() {
+				continue
+			}
+			out.Field(i).Set(p.apply(v.Field(i), p.fieldFormat(jsonName(field), f)))
+		}
+		return out
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(p.apply(v.Index(i), f))
+		}
+		return out
+	case reflect.Map:
+		if v.IsNil() || v.Type().Key().Kind() != reflect.String {
+			return v
+		}
+		out := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			out.SetMapIndex(iter.Key(), p.apply(iter.Value(), p.fieldFormat(iter.Key().String(), f)))
+		}
+		return out
+	}
+	return v
+}
+
+// fieldFormat คืนรูปแบบของ key ถ้า Policy กำหนดไว้ ไม่เช่นนั้นใช้ของ key ชั้นนอก
+func (p Policy) fieldFormat(key string, parent Format) Format {
+	if f, ok := p.Fields[key]; ok {
+		return f
+	}
+	return parent
+}
+
+// jsonName คืนชื่อ key ของ struct field ตาม json tag, field ที่ embed ไม่มีชื่อของตัวเอง
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" && !field.Anonymous {
+		return field.Name
+	}
+	return name
+}
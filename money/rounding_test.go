@@ -0,0 +1,131 @@
+package money
+
+import "testing"
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    Format
+		wantErr bool
+	}{
+		{"half-even:1", Format{RoundHalfEven, 1}, false},
+		{"half-up:2", Format{RoundHalfUp, 2}, false},
+		{"down:0", Format{RoundDown, 0}, false},
+		{"half-up", Format{}, true},
+		{"ceiling:2", Format{}, true},
+		{"half-up:-1", Format{}, true},
+		{"half-up:5", Format{}, true},
+		{"half-up:two", Format{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := ParseFormat(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFormat(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseFormat(%q) = %v, want %v", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFieldFormats(t *testing.T) {
+	got, err := ParseFieldFormats("tax=down:0, taxRefund=half-up:2")
+	if err != nil {
+		t.Fatalf("ParseFieldFormats() error = %v", err)
+	}
+	if len(got) != 2 || got["tax"] != (Format{RoundDown, 0}) || got["taxRefund"] != (Format{RoundHalfUp, 2}) {
+		t.Errorf("ParseFieldFormats() = %v", got)
+	}
+
+	for _, spec := range []string{"tax", "=down:0", "tax=down"} {
+		if _, err := ParseFieldFormats(spec); err == nil {
+			t.Errorf("ParseFieldFormats(%q) error = nil, want error", spec)
+		}
+	}
+}
+
+func TestPolicyMarshal(t *testing.T) {
+	type level struct {
+		Level string `json:"level"`
+		Tax   Money  `json:"tax"`
+	}
+	response := map[string]interface{}{
+		"tax":       New(1234.565),
+		"taxRefund": New(0.25),
+		"taxLevel":  []level{{"150,001-500,000", New(1234.565)}},
+		"lateFiling": &struct {
+			Surcharge Money `json:"surcharge"`
+			Months    int   `json:"monthsLate"`
+		}{New(10.05), 2},
+	}
+
+	tests := []struct {
+		name   string
+		policy Policy
+		want   string
+	}{
+		{
+			name:   "Default banker's rounding 1 place",
+			policy: Policy{Default: DefaultFormat},
+			want:   `{"lateFiling":{"surcharge":10.0,"monthsLate":2},"tax":1234.6,"taxLevel":[{"level":"150,001-500,000","tax":1234.6}],"taxRefund":0.2}`,
+		},
+		{
+			name:   "Ledger half-up 2 places",
+			policy: Policy{Default: Format{RoundHalfUp, 2}},
+			want:   `{"lateFiling":{"surcharge":10.05,"monthsLate":2},"tax":1234.57,"taxLevel":[{"level":"150,001-500,000","tax":1234.57}],"taxRefund":0.25}`,
+		},
+		{
+			name:   "Whole baht truncation",
+			policy: Policy{Default: Format{RoundDown, 0}},
+			want:   `{"lateFiling":{"surcharge":10,"monthsLate":2},"tax":1234,"taxLevel":[{"level":"150,001-500,000","tax":1234}],"taxRefund":0}`,
+		},
+		{
+			name:   "Field override applies to nested amounts",
+			policy: Policy{Default: DefaultFormat, Fields: map[string]Format{"taxLevel": {RoundDown, 0}, "surcharge": {RoundHalfUp, 2}}},
+			want:   `{"lateFiling":{"surcharge":10.05,"monthsLate":2},"tax":1234.6,"taxLevel":[{"level":"150,001-500,000","tax":1234}],"taxRefund":0.2}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.policy.Marshal(response)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Marshal() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	// Apply ต้องไม่แก้ไขค่าเดิม
+	if tax := response["tax"].(Money); tax.format != nil || !tax.Equal(New(1234.565)) {
+		t.Errorf("original tax changed to %v", tax)
+	}
+}
+
+func TestPolicyOverride(t *testing.T) {
+	server := DefaultPolicy()
+	ledger := Format{RoundHalfUp, 2}
+	got := server.Override(&ledger, map[string]Format{"tax": {RoundDown, 0}})
+
+	if got.Default != ledger {
+		t.Errorf("Default = %v, want %v", got.Default, ledger)
+	}
+	// รูปแบบหลักที่เลือกไม่แทนที่ field ของ server ที่ request ไม่ได้ระบุ
+	if len(got.Fields) != 2 || got.Fields["tax"] != (Format{RoundDown, 0}) || got.Fields["installmentPlan"] != server.Fields["installmentPlan"] {
+		t.Errorf("Fields = %v", got.Fields)
+	}
+	if fieldsOnly := server.Override(nil, map[string]Format{"tax": {RoundDown, 0}}); fieldsOnly.Fields["installmentPlan"] != server.Fields["installmentPlan"] {
+		t.Errorf("Override(nil, fields) dropped server field: %v", fieldsOnly.Fields)
+	}
+	if _, ok := server.Fields["tax"]; ok {
+		t.Error("Override must not modify the server policy")
+	}
+	if kept := server.Override(nil, nil); kept.Default != DefaultFormat {
+		t.Errorf("Override(nil) Default = %v, want %v", kept.Default, DefaultFormat)
+	}
+}
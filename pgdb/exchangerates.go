@@ -0,0 +1,67 @@
+package pgdb
+
+import (
+	"database/sql"
+
+	"github.com/windeesel365/assessment-tax/fxrates"
+)
+
+func CreateExchangeRatesTable(db *sql.DB) error {
+	//SQL statement เพื่อ create 'exchange_rates' table, rate_date เป็น NULL คืออัตราเฉลี่ยทั้งปีภาษี
+	createTableSQL := `
+		CREATE TABLE IF NOT EXISTS exchange_rates (
+			id SERIAL PRIMARY KEY,
+			currency CHAR(3) NOT NULL,
+			tax_year INTEGER NOT NULL,
+			rate_date DATE,
+			rate NUMERIC(18, 6) NOT NULL
+		);
+	`
+	// execute SQL statement ข้างบน
+	_, err := db.Exec(createTableSQL)
+	return err
+}
+
+// SaveExchangeRate update อัตราของสกุลเงิน ปีภาษี และวันเดียวกัน ถ้าไม่มีก็ insert ใหม่
+func SaveExchangeRate(db *sql.DB, rate fxrates.Rate) error {
+	result, err := db.Exec(`UPDATE exchange_rates SET rate = $1 WHERE currency = $2 AND tax_year = $3 AND rate_date IS NOT DISTINCT FROM $4;`,
+		rate.Rate, rate.Currency, rate.TaxYear, rate.Date)
+	if err != nil {
+		return err
+	}
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if updated > 0 {
+		return nil
+	}
+
+	_, err = db.Exec(`INSERT INTO exchange_rates(currency, tax_year, rate_date, rate) VALUES($1, $2, $3, $4);`,
+		rate.Currency, rate.TaxYear, rate.Date, rate.Rate)
+	return err
+}
+
+// GetExchangeRates อ่านอัตราแลกเปลี่ยนทั้งหมด เรียงตามสกุลเงิน ปีภาษี และวันที่
+func GetExchangeRates(db *sql.DB) ([]fxrates.Rate, error) {
+	rows, err := db.Query(`SELECT currency, tax_year, rate_date, rate FROM exchange_rates ORDER BY currency, tax_year, rate_date NULLS FIRST;`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rates []fxrates.Rate
+	for rows.Next() {
+		var rate fxrates.Rate
+		var rateDate sql.NullTime
+		if err := rows.Scan(&rate.Currency, &rate.TaxYear, &rateDate, &rate.Rate); err != nil {
+			return nil, err
+		}
+		if rateDate.Valid {
+			date := rateDate.Time
+			rate.Date = &date
+		}
+		rates = append(rates, rate)
+	}
+	return rates, rows.Err()
+}
@@ -1,15 +1,19 @@
 package pgdb
 
-import "database/sql"
+import (
+	"database/sql"
+
+	"github.com/windeesel365/assessment-tax/money"
+)
 
 type PersonalDeduction struct {
 	ID                int
-	PersonalDeduction float64
+	PersonalDeduction money.Money
 }
 
 type KReceiptDeduction struct {
 	ID                        int
-	UpperLimKReceiptDeduction float64
+	UpperLimKReceiptDeduction money.Money
 }
 
 func CreateAdminDeductionsTable(db *sql.DB) error {
@@ -26,7 +30,7 @@ func CreateAdminDeductionsTable(db *sql.DB) error {
 	return err
 }
 
-func CreateDeduction(db *sql.DB, personalDeduction float64, kReceiptDeduction float64) (int, error) {
+func CreateDeduction(db *sql.DB, personalDeduction money.Money, kReceiptDeduction money.Money) (int, error) {
 	var id int
 	err := db.QueryRow(`INSERT INTO deductions(personal_deduction, k_receipt_deduction) VALUES($1, $2) RETURNING id;`, personalDeduction, kReceiptDeduction).Scan(&id)
 	if err != nil {
@@ -68,7 +72,7 @@ func GetPersonalDeduction(db *sql.DB, id int) (PersonalDeduction, error) {
 	return deduc, nil
 }
 
-func UpdatePersonalDeduction(db *sql.DB, id int, personalDeduction float64) error {
+func UpdatePersonalDeduction(db *sql.DB, id int, personalDeduction money.Money) error {
 	_, err := db.Exec(`UPDATE deductions SET personal_deduction = $1 WHERE id = $2;`, personalDeduction, id)
 	return err
 }
@@ -83,7 +87,7 @@ func GetKReceiptDeduction(db *sql.DB, id int) (KReceiptDeduction, error) {
 	return deduc, nil
 }
 
-func UpdateKReceiptDeduction(db *sql.DB, id int, upperLimKReceiptDeduction float64) error {
+func UpdateKReceiptDeduction(db *sql.DB, id int, upperLimKReceiptDeduction money.Money) error {
 	_, err := db.Exec(`UPDATE deductions SET k_receipt_deduction = $1 WHERE id = $2;`, upperLimKReceiptDeduction, id)
 	return err
 }
@@ -1,16 +1,19 @@
 package sharedvars
 
-import "database/sql"
+import (
+	"database/sql"
+
+	"github.com/windeesel365/assessment-tax/money"
+)
 
 // initialize value
-var InitialPersonalExemption float64 = 60000.0
-var Initialdonations float64 = 0.0
-var InitialkReceipts float64 = 0.0
+var InitialPersonalExemption money.Money = money.NewFromInt(60000)
+var Initialdonations money.Money = money.Zero
+var InitialkReceipts money.Money = money.Zero
 
 // initial exemptions กับค่า limits
-var PersonalExemptionUpperLimit float64 = 100000.0
-var DonationsUpperLimit float64 = 100000.0
-var KReceiptsUpperLimit float64 = 50000.0
+var PersonalExemptionUpperLimit money.Money = money.NewFromInt(100000)
+var KReceiptsUpperLimit money.Money = money.NewFromInt(50000)
 
 // declare สำหรับ ref database และ idข้อมูล postgresql
 var Db *sql.DB
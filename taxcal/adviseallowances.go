@@ -0,0 +1,113 @@
+package taxcal
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/windeesel365/assessment-tax/money"
+)
+
+// AllowanceAdvice คือสิทธิลดหย่อนที่ยังเหลือของค่าลดหย่อนหนึ่งชนิด
+// Applied, Limit และ Headroom เป็นยอดที่หักได้ ซึ่งเงินบริจาค 2 เท่าคือ 2 เท่าของเงินที่จ่ายจริง
+type AllowanceAdvice struct {
+	AllowanceType string      `json:"allowanceType"`
+	Applied       money.Money `json:"applied"`
+	Limit         money.Money `json:"limit"`
+	Headroom      money.Money `json:"headroom"`
+	TaxSaving     money.Money `json:"taxSaving"`
+}
+
+// TaxAdvice คือผลของ AdviseAllowances
+type TaxAdvice struct {
+	Result       TaxResult
+	MarginalRate decimal.Decimal
+	Allowances   []AllowanceAdvice
+}
+
+// AdviseAllowances หาสิทธิลดหย่อนที่ยังเหลือ (headroom) ของแต่ละชนิดใน registry
+// และภาษีที่จะประหยัดได้ถ้าใช้สิทธิที่เหลือเต็มจำนวน โดยคำนวนใหม่ด้วย Calculate
+// ที่ขั้นบันใดภาษีเดียวกันจะเท่ากับ headroom x marginal rate
+func AdviseAllowances(in TaxInput) (TaxAdvice, error) {
+	result, err := Calculate(in)
+	if err != nil {
+		return TaxAdvice{}, err
+	}
+	brackets, err := TaxBracketsFor(in.TaxYear)
+	if err != nil {
+		return TaxAdvice{}, err
+	}
+
+	advice := TaxAdvice{
+		Result:       result,
+		MarginalRate: MarginalBracket(result.TaxableIncome, brackets).Rate,
+	}
+
+	// ยอดที่ใช้ไปแล้วของแต่ละ AllowanceGroup
+	groupUsed := map[string]money.Money{}
+	for i, rule := range allowanceRegistry {
+		if rule.Group != "" {
+			groupUsed[rule.Group] = groupUsed[rule.Group].Add(result.Allowances[i].Amount)
+		}
+	}
+
+	for i, rule := range allowanceRegistry {
+		limit, ok := rule.limit(result.GrossIncome)
+		group, inGroup := lookupAllowanceGroup(rule.Group)
+		groupCap := money.Zero
+		if inGroup {
+			groupCap = groupLimit(group, result)
+			// group แบบ NetIncomeCap เป็นเพดานของแต่ละชนิดด้วย เช่น เงินบริจาค
+			if group.NetIncomeCap != nil && (!ok || groupCap.LessThan(limit)) {
+				limit, ok = groupCap, true
+			}
+		}
+		if !ok || rule.ExcludeFromAdvice {
+			continue
+		}
+
+		applied := result.Allowances[i].Amount
+		headroom := money.Max(limit.Sub(applied), money.Zero)
+		if inGroup {
+			headroom = money.Min(headroom, money.Max(groupCap.Sub(groupUsed[group.Name]), money.Zero))
+		}
+
+		taxSaving := money.Zero
+		if headroom.IsPositive() {
+			// เงินบริจาค 2 เท่า จ่ายจริงเพียงครึ่งหนึ่งของ headroom
+			claim := applied.Add(headroom).DivInt(rule.multiplier())
+			withHeadroom, err := Calculate(withAllowanceClaim(in, rule.Name, claim))
+			if err != nil {
+				return TaxAdvice{}, err
+			}
+			taxSaving = result.Tax.Sub(withHeadroom.Tax)
+		}
+
+		advice.Allowances = append(advice.Allowances, AllowanceAdvice{
+			AllowanceType: rule.Name,
+			Applied:       applied,
+			Limit:         limit,
+			Headroom:      headroom,
+			TaxSaving:     taxSaving,
+		})
+	}
+
+	return advice, nil
+}
+
+// groupLimit คือเพดานรวมของ group ที่ใช้ในผลการคำนวน
+func groupLimit(group AllowanceGroup, result TaxResult) money.Money {
+	if group.NetIncomeCap != nil {
+		return result.NetIncomeCaps[group.Name]
+	}
+	return group.Cap()
+}
+
+// withAllowanceClaim คืน input ใหม่ที่ claim ของ allowanceType เป็น amount (ไม่แก้ input เดิม)
+func withAllowanceClaim(in TaxInput, allowanceType string, amount money.Money) TaxInput {
+	claims := make([]AllowanceClaim, 0, len(in.Allowances)+1)
+	for _, claim := range in.Allowances {
+		if claim.AllowanceType != allowanceType {
+			claims = append(claims, claim)
+		}
+	}
+	in.Allowances = append(claims, AllowanceClaim{AllowanceType: allowanceType, Amount: amount})
+	return in
+}
@@ -0,0 +1,62 @@
+package taxcal
+
+import (
+	"testing"
+
+	"github.com/windeesel365/assessment-tax/money"
+)
+
+func TestAdviseAllowances(t *testing.T) {
+	in := TaxInput{
+		TotalIncome: money.NewFromInt(1000000),
+		Allowances: []AllowanceClaim{
+			{AllowanceType: "k-receipt", Amount: money.NewFromInt(20000)},
+			{AllowanceType: "rmf", Amount: money.NewFromInt(400000)},
+			{AllowanceType: "ssf", Amount: money.NewFromInt(200000)},
+		},
+	}
+
+	advice, err := AdviseAllowances(in)
+	if err != nil {
+		t.Fatalf("AdviseAllowances() error = %v", err)
+	}
+
+	// 1,000,000 - 60,000 - 20,000 - 200,000 (ssf) - 300,000 (rmf 30% ของเงินได้) = 420,000 -> 10%
+	if !advice.MarginalRate.Equal(money.Rate(0.10)) {
+		t.Errorf("AdviseAllowances() MarginalRate = %v, want 0.10", advice.MarginalRate)
+	}
+
+	tests := []struct {
+		allowanceType string
+		headroom      money.Money
+		taxSaving     money.Money
+	}{
+		{"k-receipt", money.NewFromInt(30000), money.NewFromInt(3000)},
+		// retirement group ใช้ครบ 500,000 แล้ว
+		{"ssf", money.Zero, money.Zero},
+		{"rmf", money.Zero, money.Zero},
+		// 420,000 -> 320,000 ยังอยู่ขั้น 10% ทั้งหมด
+		{"life-insurance", money.NewFromInt(100000), money.NewFromInt(10000)},
+		// ไม่อยู่ใน group ใด headroom เต็มเพดาน 100,000
+		{"home-loan-interest", money.NewFromInt(100000), money.NewFromInt(10000)},
+	}
+
+	found := map[string]AllowanceAdvice{}
+	for _, a := range advice.Allowances {
+		found[a.AllowanceType] = a
+	}
+	if _, ok := found["personal"]; ok {
+		t.Error("AdviseAllowances() should not advise on personal allowance")
+	}
+	for _, tt := range tests {
+		t.Run(tt.allowanceType, func(t *testing.T) {
+			got, ok := found[tt.allowanceType]
+			if !ok {
+				t.Fatalf("AdviseAllowances() missing %s", tt.allowanceType)
+			}
+			if !got.Headroom.Equal(tt.headroom) || !got.TaxSaving.Equal(tt.taxSaving) {
+				t.Errorf("AdviseAllowances() %s = %v, %v; want %v, %v", tt.allowanceType, got.Headroom, got.TaxSaving, tt.headroom, tt.taxSaving)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package taxcal
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/windeesel365/assessment-tax/i18n"
+	"github.com/windeesel365/assessment-tax/money"
+	"github.com/windeesel365/assessment-tax/sharedvars"
+)
+
+// AllowanceRule คือกฎของค่าลดหย่อนหนึ่งชนิด ที่ลงทะเบียนไว้ใน registry
+// การเพิ่มค่าลดหย่อนชนิดใหม่ทำได้ด้วย RegisterAllowance ครั้งเดียว
+type AllowanceRule struct {
+	// Name คือ allowanceType ที่ client ส่งมา
+	Name string
+	// Min คือ amount ขั้นต่ำ, MinExclusive เป็น true เมื่อ amount ต้องมากกว่า Min
+	Min          money.Money
+	MinExclusive bool
+	// MinMessage คือ error ที่แสดงเมื่อ amount ไม่ผ่าน Min
+	MinMessage i18n.Message
+	// Default คือยอดที่หักได้เมื่อ client ไม่ได้ส่งมา (nil คือ 0)
+	Default func() money.Money
+	// Claimed แปลง amount ที่ client ส่งมาเป็นยอดที่ใช้หัก ก่อนคูณ Multiplier และใช้เพดาน (nil คือใช้ amount ตามที่ส่งมา)
+	Claimed func(amount money.Money) money.Money
+	// ClaimedRule อธิบายใน trace ว่า Claimed เปลี่ยน amount อย่างไร
+	ClaimedRule func() i18n.Message
+	// Cap คือเพดานแบบ fixed หรือให้ admin ปรับได้ (nil คือไม่มีเพดาน)
+	Cap func() money.Money
+	// IncomeCap คือเพดานที่ขึ้นกับเงินได้ (nil คือไม่ขึ้นกับเงินได้)
+	IncomeCap func(income money.Money) money.Money
+	// Group คือชื่อ AllowanceGroup ที่ใช้เพดานรวมร่วมกัน (ว่างคือไม่มีเพดานรวม)
+	Group string
+	// Multiplier คือจำนวนเท่าของ amount ที่หักได้ก่อนใช้เพดาน เช่น เงินบริจาคเพื่อการศึกษา 2 เท่า (0 คือ 1 เท่า)
+	Multiplier int64
+	// Donation เป็น true สำหรับเงินบริจาค ซึ่ง response แสดงยอดที่หักได้จริง
+	Donation bool
+	// ExcludeFromAdvice เป็น true สำหรับค่าลดหย่อนที่ผู้เสียภาษีเพิ่มเองไม่ได้ เช่น ค่าลดหย่อนส่วนตัว
+	ExcludeFromAdvice bool
+}
+
+// AllowanceGroup คือเพดานรวมของค่าลดหย่อนหลายชนิด เช่น กลุ่มเงินออมเพื่อการเกษียณ
+// Cap คือเพดานรวมคงที่, NetIncomeCap คือเพดานรวมที่คิดจากเงินได้หลังหักค่าลดหย่อนอื่นแล้ว เช่น เงินบริจาค 10%
+// group แบบ NetIncomeCap หักหลังค่าลดหย่อนอื่นทั้งหมด ตามลำดับการลงทะเบียน group
+type AllowanceGroup struct {
+	Name         string
+	Cap          func() money.Money
+	NetIncomeCap func(netIncome money.Money) money.Money
+}
+
+// registry เก็บตามลำดับการลงทะเบียน เพื่อให้ผลลัพธ์เรียงเหมือนกันทุกครั้ง
+var allowanceRegistry []AllowanceRule
+var allowanceGroups []AllowanceGroup
+
+// RegisterAllowance ลงทะเบียนค่าลดหย่อนชนิดใหม่, ชื่อซ้ำถือเป็น programming error
+func RegisterAllowance(rule AllowanceRule) {
+	if _, ok := LookupAllowance(rule.Name); ok {
+		panic(fmt.Sprintf("taxcal: allowance %q registered twice", rule.Name))
+	}
+	allowanceRegistry = append(allowanceRegistry, rule)
+}
+
+// RegisterAllowanceGroup ลงทะเบียนเพดานรวม, ชนิดที่อ้าง Group นี้จะถูกจำกัดยอดรวมไม่เกิน Cap
+func RegisterAllowanceGroup(group AllowanceGroup) {
+	if _, ok := lookupAllowanceGroup(group.Name); ok {
+		panic(fmt.Sprintf("taxcal: allowance group %q registered twice", group.Name))
+	}
+	allowanceGroups = append(allowanceGroups, group)
+}
+
+// LookupAllowance หา AllowanceRule จาก allowanceType
+func LookupAllowance(name string) (AllowanceRule, bool) {
+	for _, rule := range allowanceRegistry {
+		if rule.Name == name {
+			return rule, true
+		}
+	}
+	return AllowanceRule{}, false
+}
+
+// AllowanceTypes คืนชื่อ allowanceType ทั้งหมดตามลำดับการลงทะเบียน
+func AllowanceTypes() []string {
+	names := make([]string, 0, len(allowanceRegistry))
+	for _, rule := range allowanceRegistry {
+		names = append(names, rule.Name)
+	}
+	return names
+}
+
+// FixedCap เพดานคงที่ที่ไม่เปลี่ยนตาม admin
+func FixedCap(amount money.Money) func() money.Money {
+	return func() money.Money { return amount }
+}
+
+// lookupAllowanceGroup หา AllowanceGroup จากชื่อ
+func lookupAllowanceGroup(name string) (AllowanceGroup, bool) {
+	for _, group := range allowanceGroups {
+		if group.Name == name {
+			return group, true
+		}
+	}
+	return AllowanceGroup{}, false
+}
+
+// multiplier คืน Multiplier ของกฎ โดย 0 คือ 1 เท่า
+func (rule AllowanceRule) multiplier() int64 {
+	if rule.Multiplier == 0 {
+		return 1
+	}
+	return rule.Multiplier
+}
+
+// validateMin เช็ค amount ตาม Min ของกฎ
+func (rule AllowanceRule) validateMin(amount money.Money) error {
+	if amount.LessThan(rule.Min) || (rule.MinExclusive && amount.Equal(rule.Min)) {
+		return &i18n.Error{Message: rule.MinMessage}
+	}
+	return nil
+}
+
+// limit หาเพดานที่ใช้จริง ณ เงินได้นั้น (ค่าที่น้อยที่สุดของ Cap และ IncomeCap)
+// ok เป็น false ถ้าไม่มีเพดานเลย
+func (rule AllowanceRule) limit(income money.Money) (limit money.Money, ok bool) {
+	if rule.Cap != nil {
+		limit, ok = rule.Cap(), true
+	}
+	if rule.IncomeCap != nil {
+		incomeCap := rule.IncomeCap(income)
+		if !ok || incomeCap.LessThan(limit) {
+			limit, ok = incomeCap, true
+		}
+	}
+	return limit, ok
+}
+
+// personalExemption คงพฤติกรรมเดิมของค่าลดหย่อนส่วนตัว: amount ที่ไม่เกินเพดานได้ InitialPersonalExemption
+// ส่วน amount ที่เกินเพดานได้ PersonalExemptionUpperLimit
+func personalExemption(amount money.Money) money.Money {
+	if amount.GreaterThan(sharedvars.PersonalExemptionUpperLimit) {
+		return sharedvars.PersonalExemptionUpperLimit
+	}
+	return sharedvars.InitialPersonalExemption
+}
+
+// personalExemptionRule อธิบาย personalExemption ใน trace
+func personalExemptionRule() i18n.Message {
+	return i18n.Msg("claims up to %s get %s", formatTraceAmount(sharedvars.PersonalExemptionUpperLimit), formatTraceAmount(sharedvars.InitialPersonalExemption))
+}
+
+func allowanceTypesMessage() string {
+	return strings.Join(AllowanceTypes(), ", ")
+}
+
+// ค่าลดหย่อนพื้นฐาน 3 ชนิด: ค่าลดหย่อนส่วนตัว/เงินบริจาค/ช้อปปลดภาษี
+func init() {
+	RegisterAllowance(AllowanceRule{
+		Name:         "personal",
+		Min:          money.NewFromInt(10000),
+		MinExclusive: true,
+		MinMessage:   i18n.Text("The personal exemption must be more than 10,000 THB.  Please update the amount and try again."),
+		Default:      func() money.Money { return sharedvars.InitialPersonalExemption },
+		Cap:          func() money.Money { return sharedvars.PersonalExemptionUpperLimit },
+		Claimed:      personalExemption,
+		ClaimedRule:  personalExemptionRule,
+
+		ExcludeFromAdvice: true,
+	})
+	// เงินบริจาคทั่วไปไม่เกิน 10% ของเงินได้หลังหักค่าลดหย่อนอื่น ดู donationallowances.go
+	RegisterAllowance(AllowanceRule{
+		Name:       "donation",
+		Min:        money.Zero,
+		MinMessage: i18n.Text("The donation must be more than 0 THB. Please enter a positive amount and try again."),
+		Default:    func() money.Money { return sharedvars.Initialdonations },
+		Group:      GeneralDonationGroup,
+		Donation:   true,
+	})
+	RegisterAllowance(AllowanceRule{
+		Name:         "k-receipt",
+		Min:          money.Zero,
+		MinExclusive: true,
+		MinMessage:   i18n.Text("The kReceipts must be more than 0 THB. Please enter a positive amount and try again."),
+		Default:      func() money.Money { return sharedvars.InitialkReceipts },
+		Cap:          func() money.Money { return sharedvars.KReceiptsUpperLimit },
+	})
+}
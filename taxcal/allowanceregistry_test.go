@@ -0,0 +1,55 @@
+package taxcal
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/windeesel365/assessment-tax/money"
+)
+
+func TestAllowanceTypes(t *testing.T) {
+	want := []string{"personal", "donation", "k-receipt"}
+	if got := AllowanceTypes(); !reflect.DeepEqual(got[:len(want)], want) {
+		t.Errorf("AllowanceTypes() = %v, want prefix %v", got, want)
+	}
+}
+
+func TestRegisterAllowance(t *testing.T) {
+	saved := allowanceRegistry
+	defer func() { allowanceRegistry = saved }()
+
+	RegisterAllowance(AllowanceRule{
+		Name:      "test-income-capped",
+		Cap:       FixedCap(money.NewFromInt(200000)),
+		IncomeCap: func(income money.Money) money.Money { return income.Mul(money.Rate(0.3)) },
+	})
+
+	rule, ok := LookupAllowance("test-income-capped")
+	if !ok {
+		t.Fatal("LookupAllowance() did not find registered allowance")
+	}
+
+	tests := []struct {
+		name   string
+		income money.Money
+		want   money.Money
+	}{
+		{"Income cap lower", money.NewFromInt(100000), money.NewFromInt(30000)},
+		{"Fixed cap lower", money.NewFromInt(1000000), money.NewFromInt(200000)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limit, ok := rule.limit(tt.income)
+			if !ok || !limit.Equal(tt.want) {
+				t.Errorf("limit(%v) = %v, %v; want %v", tt.income, limit, ok, tt.want)
+			}
+		})
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("RegisterAllowance() with duplicate name did not panic")
+		}
+	}()
+	RegisterAllowance(AllowanceRule{Name: "personal"})
+}
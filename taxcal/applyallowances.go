@@ -0,0 +1,153 @@
+package taxcal
+
+import (
+	"github.com/windeesel365/assessment-tax/i18n"
+	"github.com/windeesel365/assessment-tax/money"
+)
+
+// AllowanceClaim คือค่าลดหย่อนหนึ่งรายการที่ client ส่งมา
+type AllowanceClaim struct {
+	AllowanceType string      `json:"allowanceType"`
+	Amount        money.Money `json:"amount"`
+}
+
+// AppliedAllowance คือค่าลดหย่อนหลังผ่านกฎใน registry แล้ว
+type AppliedAllowance struct {
+	AllowanceType string      `json:"allowanceType"`
+	Claimed       money.Money `json:"claimed"`
+	Amount        money.Money `json:"amount"`
+	// Limit คือเพดานที่ใช้จริงกับชนิดนี้ รวมเพดานรวมของ group แล้ว (nil คือไม่มีเพดาน) สำหรับ trace
+	Limit *money.Money `json:"-"`
+	// Substituted เป็น true เมื่อ AllowanceRule.Claimed เปลี่ยนยอดที่หักได้ สำหรับ trace
+	Substituted bool `json:"-"`
+}
+
+// ApplyAllowances ใช้กฎใน registry กับค่าลดหย่อนที่ client ส่งมา
+// ผลลัพธ์มีครบทุกชนิดใน registry ตามลำดับการลงทะเบียน ชนิดที่ไม่ได้ส่งมาใช้ Default
+// เพดานของ group แบบ NetIncomeCap ยังไม่ถูกใช้ ต้องเรียก ApplyNetIncomeCaps ต่อ
+func ApplyAllowances(claims []AllowanceClaim, income money.Money) ([]AppliedAllowance, error) {
+	claimed := map[string]money.Money{}
+
+	for _, claim := range claims {
+		rule, ok := LookupAllowance(claim.AllowanceType)
+		if !ok {
+			return nil, i18n.Errorf("invalid allowance type. Please ensure the filled type is one of: %s", allowanceTypesMessage())
+		}
+		if _, redundant := claimed[rule.Name]; redundant {
+			return nil, i18n.Errorf("allowanceType %s is redundant, please check and fill again", rule.Name)
+		}
+		if err := rule.validateMin(claim.Amount); err != nil {
+			return nil, err
+		}
+		claimed[rule.Name] = claim.Amount
+	}
+
+	applied := make([]AppliedAllowance, 0, len(allowanceRegistry))
+	for _, rule := range allowanceRegistry {
+		amount, ok := claimed[rule.Name]
+		if !ok {
+			amount = money.Zero
+			if rule.Default != nil {
+				amount = rule.Default()
+			}
+		}
+		deductible := amount
+		if ok && rule.Claimed != nil {
+			deductible = rule.Claimed(amount)
+		}
+		allowance := AppliedAllowance{
+			AllowanceType: rule.Name,
+			Claimed:       amount,
+			Amount:        deductible.MulInt(rule.multiplier()),
+		}
+		asClaimed := amount.MulInt(rule.multiplier())
+		if limit, ok := rule.limit(income); ok {
+			allowance.applyLimit(limit)
+			asClaimed = money.Min(asClaimed, limit)
+		}
+		// Claimed ให้ยอดต่างจากที่ส่งมาหลังใช้เพดาน ยอดนั้นเป็นเพดานของชนิดนี้ด้วย
+		if !allowance.Amount.Equal(asClaimed) {
+			allowance.Substituted = true
+			allowance.applyLimit(allowance.Amount)
+		}
+		applied = append(applied, allowance)
+	}
+
+	applyAllowanceGroups(applied)
+
+	return applied, nil
+}
+
+// applyAllowanceGroups จำกัดยอดรวมของแต่ละ AllowanceGroup
+// ชนิดที่ลงทะเบียนก่อนได้ใช้เพดานรวมก่อน, applied ต้องเรียงตาม allowanceRegistry
+func applyAllowanceGroups(applied []AppliedAllowance) {
+	for _, group := range allowanceGroups {
+		if group.Cap == nil {
+			continue
+		}
+		remaining := group.Cap()
+		for i, rule := range allowanceRegistry {
+			if rule.Group != group.Name {
+				continue
+			}
+			applied[i].applyLimit(group.Cap())
+			applied[i].Amount = money.Min(applied[i].Amount, remaining)
+			remaining = remaining.Sub(applied[i].Amount)
+		}
+	}
+}
+
+// applyLimit จำกัด Amount ไม่เกิน limit และเก็บเพดานที่ต่ำที่สุดที่ใช้ไว้ใน Limit
+func (allowance *AppliedAllowance) applyLimit(limit money.Money) {
+	if allowance.Limit == nil || limit.LessThan(*allowance.Limit) {
+		allowance.Limit = &limit
+	}
+	allowance.Amount = money.Min(allowance.Amount, limit)
+}
+
+// ApplyNetIncomeCaps จำกัดยอดของ group แบบ NetIncomeCap เช่น เงินบริจาค 10%
+// income คือเงินได้หลังหักค่าใช้จ่ายและค่าลดหย่อนครอบครัว, ค่าลดหย่อนที่ไม่อยู่ใน group แบบนี้ถูกหักก่อน
+// แล้วแต่ละ group หักตามลำดับการลงทะเบียน โดยเพดานคิดจากเงินได้ที่เหลือ ณ ตอนนั้น
+// คืนเพดานที่ใช้จริงของแต่ละ group
+func ApplyNetIncomeCaps(applied []AppliedAllowance, income money.Money) map[string]money.Money {
+	netIncomeGroup := func(rule AllowanceRule) bool {
+		group, ok := lookupAllowanceGroup(rule.Group)
+		return ok && group.NetIncomeCap != nil
+	}
+
+	remaining := income
+	for i, rule := range allowanceRegistry {
+		if !netIncomeGroup(rule) {
+			remaining = remaining.Sub(applied[i].Amount)
+		}
+	}
+
+	caps := map[string]money.Money{}
+	for _, group := range allowanceGroups {
+		if group.NetIncomeCap == nil {
+			continue
+		}
+		limit := group.NetIncomeCap(money.Max(remaining, money.Zero))
+		caps[group.Name] = limit
+		for i, rule := range allowanceRegistry {
+			if rule.Group != group.Name {
+				continue
+			}
+			applied[i].applyLimit(caps[group.Name])
+			applied[i].Amount = money.Min(applied[i].Amount, limit)
+			limit = limit.Sub(applied[i].Amount)
+			remaining = remaining.Sub(applied[i].Amount)
+		}
+	}
+
+	return caps
+}
+
+// AllowanceAmounts คืนยอดที่หักได้จริงของทุกชนิด สำหรับส่งต่อให้ CaltaxableIncome
+func AllowanceAmounts(applied []AppliedAllowance) []money.Money {
+	amounts := make([]money.Money, 0, len(applied))
+	for _, allowance := range applied {
+		amounts = append(amounts, allowance.Amount)
+	}
+	return amounts
+}
@@ -0,0 +1,120 @@
+package taxcal
+
+import (
+	"testing"
+
+	"github.com/windeesel365/assessment-tax/money"
+)
+
+func TestApplyAllowances(t *testing.T) {
+	tests := []struct {
+		name    string
+		claims  []AllowanceClaim
+		want    map[string]money.Money
+		wantErr bool
+	}{
+		{
+			name:   "Defaults only",
+			claims: nil,
+			want: map[string]money.Money{
+				"personal":  money.NewFromInt(60000),
+				"donation":  money.Zero,
+				"k-receipt": money.Zero,
+			},
+		},
+		{
+			name: "Caps applied",
+			claims: []AllowanceClaim{
+				{AllowanceType: "k-receipt", Amount: money.NewFromInt(200000)},
+				{AllowanceType: "donation", Amount: money.NewFromInt(200000)},
+				{AllowanceType: "personal", Amount: money.NewFromInt(150000)},
+			},
+			want: map[string]money.Money{
+				"personal": money.NewFromInt(100000),
+				// เพดาน 10% ของเงินบริจาคใช้ใน ApplyNetIncomeCaps
+				"donation":  money.NewFromInt(200000),
+				"k-receipt": money.NewFromInt(50000),
+			},
+		},
+		{
+			name:   "Personal within limit keeps initial exemption",
+			claims: []AllowanceClaim{{AllowanceType: "personal", Amount: money.NewFromInt(70000)}},
+			want: map[string]money.Money{
+				"personal": money.NewFromInt(60000),
+			},
+		},
+		{
+			name:   "Personal below initial exemption gets initial exemption",
+			claims: []AllowanceClaim{{AllowanceType: "personal", Amount: money.NewFromInt(20000)}},
+			want: map[string]money.Money{
+				"personal": money.NewFromInt(60000),
+			},
+		},
+		{
+			name:    "Personal below minimum",
+			claims:  []AllowanceClaim{{AllowanceType: "personal", Amount: money.NewFromInt(10000)}},
+			wantErr: true,
+		},
+		{
+			name:    "Zero k-receipt",
+			claims:  []AllowanceClaim{{AllowanceType: "k-receipt", Amount: money.Zero}},
+			wantErr: true,
+		},
+		{
+			name: "Redundant type",
+			claims: []AllowanceClaim{
+				{AllowanceType: "donation", Amount: money.NewFromInt(100)},
+				{AllowanceType: "donation", Amount: money.NewFromInt(100)},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "Unknown type",
+			claims:  []AllowanceClaim{{AllowanceType: "personalDeduction", Amount: money.NewFromInt(100)}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			applied, err := ApplyAllowances(tt.claims, money.NewFromInt(500000))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ApplyAllowances() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, allowance := range applied {
+				want, ok := tt.want[allowance.AllowanceType]
+				if ok && !allowance.Amount.Equal(want) {
+					t.Errorf("ApplyAllowances() %s = %v, want %v", allowance.AllowanceType, allowance.Amount, want)
+				}
+			}
+		})
+	}
+}
+
+func TestApplyAllowancesLimit(t *testing.T) {
+	claims := []AllowanceClaim{
+		{AllowanceType: "k-receipt", Amount: money.NewFromInt(200000)},
+		{AllowanceType: "donation", Amount: money.NewFromInt(100000)},
+	}
+	applied, err := ApplyAllowances(claims, money.NewFromInt(500000))
+	if err != nil {
+		t.Fatalf("ApplyAllowances() error = %v", err)
+	}
+	ApplyNetIncomeCaps(applied, money.NewFromInt(500000))
+
+	// เงินบริจาคไม่เกิน 10% ของ 500,000 - 60,000 - 50,000
+	want := map[string]money.Money{
+		"personal":  money.NewFromInt(100000),
+		"donation":  money.NewFromInt(39000),
+		"k-receipt": money.NewFromInt(50000),
+	}
+	for _, allowance := range applied {
+		limit, ok := want[allowance.AllowanceType]
+		if !ok {
+			continue
+		}
+		if allowance.Limit == nil || !allowance.Limit.Equal(limit) {
+			t.Errorf("ApplyAllowances() %s limit = %v, want %v", allowance.AllowanceType, allowance.Limit, limit)
+		}
+	}
+}
@@ -3,63 +3,57 @@ package taxcal
 import (
 	"fmt"
 	"strconv"
+
+	"github.com/shopspring/decimal"
+	"github.com/windeesel365/assessment-tax/i18n"
+	"github.com/windeesel365/assessment-tax/money"
 )
 
 type TaxLevel struct {
-	Level string        `json:"level"`
-	Tax   CustomFloat64 `json:"tax"`
+	Level string      `json:"level"`
+	Tax   money.Money `json:"tax"`
 }
 
-func CalculateTaxLevelDetails(taxableIncome float64) []TaxLevel {
-	taxLevels := []struct {
-		Min  float64
-		Max  float64
-		Rate float64
-	}{
-		{0, 150000, 0},
-		{150001, 500000, 0.1},
-		{500001, 1000000, 0.15},
-		{1000001, 2000000, 0.2},
-		{2000001, -1, 0.35}, // -1 เพื่อเป็นค่าแสดง no upper limit ทางบวก
-	}
-
+// CalculateTaxLevelDetails แจกแจง tax ตามขั้นบันใดภาษีของตาราง brackets, label ของขั้นแสดงเป็นภาษา lang
+func CalculateTaxLevelDetails(taxableIncome money.Money, brackets []TaxBracket, lang i18n.Lang) []TaxLevel {
 	var taxLevelDetails []TaxLevel
 
-	for _, level := range taxLevels {
-		levelStr := formatLevelString(level.Min, level.Max)
-		var tax float64
-		if level.Max == -1 || taxableIncome <= level.Max {
+	for _, level := range brackets {
+		levelStr := formatLevelString(level, lang)
+		var tax money.Money
+		if !level.hasUpperLimit() || taxableIncome.LessThanOrEqual(level.Max) {
 			tax = calculateTaxWithinRange(taxableIncome, level.Min, level.Rate)
 		} else {
 			tax = calculateTaxWithinRange(level.Max, level.Min, level.Rate)
 		}
-		if tax <= 0 {
-			tax = 0
+		if !tax.IsPositive() {
+			tax = money.Zero
 		}
-		taxLevelDetails = append(taxLevelDetails, TaxLevel{Level: levelStr, Tax: CustomFloat64(tax)})
+		taxLevelDetails = append(taxLevelDetails, TaxLevel{Level: levelStr, Tax: tax})
 	}
 
 	return taxLevelDetails
 }
 
-func calculateTaxWithinRange(income, min, rate float64) float64 {
+func calculateTaxWithinRange(income, min money.Money, rate decimal.Decimal) money.Money {
 	// min ต้อง -1 ด้วย; เพราะเรา define taxLevels ขอบล่างลงท้าย 1
-	return (income - (min - 1)) * rate
+	return income.Sub(min.Sub(money.NewFromInt(1))).Mul(rate)
 }
 
-func formatLevelString(min, max float64) string {
-	if max == -1 {
-		return fmt.Sprintf("%s ขึ้นไป", formatAmount(min))
+// formatLevelString แสดงช่วงของขั้นบันใด, ขั้นสุดท้ายที่ไม่มีขอบบนแสดงตามภาษา lang
+func formatLevelString(level TaxBracket, lang i18n.Lang) string {
+	if !level.hasUpperLimit() {
+		return i18n.Msg("%s ขึ้นไป", formatAmount(level.Min)).In(lang)
 	}
-	return fmt.Sprintf("%s-%s", formatAmount(min), formatAmount(max))
+	return fmt.Sprintf("%s-%s", formatAmount(level.Min), formatAmount(level.Max))
 }
 
-func formatAmount(amount float64) string {
-	intPart := int64(amount)
+func formatAmount(amount money.Money) string {
+	intPart := amount.IntPart()
 	intPartStr := strconv.FormatInt(intPart, 10)
 	length := len(intPartStr)
 	if length <= 3 {
-		return strconv.FormatFloat(amount, 'f', 0, 64)
+		return intPartStr
 	}
 	remainder := length % 3
 	var formatted string
@@ -1,67 +1,115 @@
 package taxcal
 
 import (
-	"reflect"
 	"testing"
+
+	"github.com/windeesel365/assessment-tax/i18n"
+	"github.com/windeesel365/assessment-tax/money"
 )
 
 func TestCalculateTaxLevelDetails(t *testing.T) {
 	tests := []struct {
 		name          string
-		taxableIncome float64
+		taxableIncome money.Money
 		expected      []TaxLevel
 	}{
 		{
 			name:          "Zero Income",
-			taxableIncome: 0,
+			taxableIncome: money.New(0),
 			expected: []TaxLevel{
-				{"0-150,000", 0},
-				{"150,001-500,000", 0},
-				{"500,001-1,000,000", 0},
-				{"1,000,001-2,000,000", 0},
-				{"2,000,001 ขึ้นไป", 0},
+				{"0-150,000", money.New(0)},
+				{"150,001-500,000", money.New(0)},
+				{"500,001-1,000,000", money.New(0)},
+				{"1,000,001-2,000,000", money.New(0)},
+				{"2,000,001 ขึ้นไป", money.New(0)},
 			},
 		},
 		{
 			name:          "Edge of First Bracket",
-			taxableIncome: 150000,
+			taxableIncome: money.New(150000),
 			expected: []TaxLevel{
-				{"0-150,000", 0},
-				{"150,001-500,000", 0},
-				{"500,001-1,000,000", 0},
-				{"1,000,001-2,000,000", 0},
-				{"2,000,001 ขึ้นไป", 0},
+				{"0-150,000", money.New(0)},
+				{"150,001-500,000", money.New(0)},
+				{"500,001-1,000,000", money.New(0)},
+				{"1,000,001-2,000,000", money.New(0)},
+				{"2,000,001 ขึ้นไป", money.New(0)},
 			},
 		},
 		{
 			name:          "Middle Bracket",
-			taxableIncome: 750000,
+			taxableIncome: money.New(750000),
 			expected: []TaxLevel{
-				{"0-150,000", 0},
-				{"150,001-500,000", 35000},
-				{"500,001-1,000,000", 37500},
-				{"1,000,001-2,000,000", 0},
-				{"2,000,001 ขึ้นไป", 0},
+				{"0-150,000", money.New(0)},
+				{"150,001-500,000", money.New(35000)},
+				{"500,001-1,000,000", money.New(37500)},
+				{"1,000,001-2,000,000", money.New(0)},
+				{"2,000,001 ขึ้นไป", money.New(0)},
 			},
 		},
 		{
 			name:          "No Upper Limit",
-			taxableIncome: 2500000,
+			taxableIncome: money.New(2500000),
 			expected: []TaxLevel{
-				{"0-150,000", 0},
-				{"150,001-500,000", 35000},
-				{"500,001-1,000,000", 75000},
-				{"1,000,001-2,000,000", 200000},
-				{"2,000,001 ขึ้นไป", 175000},
+				{"0-150,000", money.New(0)},
+				{"150,001-500,000", money.New(35000)},
+				{"500,001-1,000,000", money.New(75000)},
+				{"1,000,001-2,000,000", money.New(200000)},
+				{"2,000,001 ขึ้นไป", money.New(175000)},
 			},
 		},
 	}
 
+	brackets, err := TaxBracketsFor(2567)
+	if err != nil {
+		t.Fatal(err)
+	}
+
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := CalculateTaxLevelDetails(tt.taxableIncome)
-			if !reflect.DeepEqual(result, tt.expected) {
-				t.Errorf("calculateTaxLevelDetails(%f) = %v, want %v", tt.taxableIncome, result, tt.expected)
+			result := CalculateTaxLevelDetails(tt.taxableIncome, brackets, "")
+			if !equalTaxLevels(result, tt.expected) {
+				t.Errorf("calculateTaxLevelDetails(%v) = %v, want %v", tt.taxableIncome, result, tt.expected)
+			}
+		})
+	}
+}
+
+// equalTaxLevels เทียบ TaxLevel ด้วยค่าเงิน เพราะ decimal ที่เท่ากันอาจมี exponent ต่างกัน
+func equalTaxLevels(got, want []TaxLevel) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for i := range got {
+		if got[i].Level != want[i].Level || !got[i].Tax.Equal(want[i].Tax) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestCalculateTaxLevelDetailsLang(t *testing.T) {
+	tests := []struct {
+		lang i18n.Lang
+		want string
+	}{
+		{"", "2,000,001 ขึ้นไป"},
+		{i18n.Thai, "2,000,001 ขึ้นไป"},
+		{i18n.English, "2,000,001 and over"},
+	}
+
+	brackets, err := TaxBracketsFor(2567)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.lang), func(t *testing.T) {
+			levels := CalculateTaxLevelDetails(money.New(0), brackets, tt.lang)
+			if got := levels[len(levels)-1].Level; got != tt.want {
+				t.Errorf("last level = %q, want %q", got, tt.want)
+			}
+			if got := levels[0].Level; got != "0-150,000" {
+				t.Errorf("first level = %q, want %q", got, "0-150,000")
 			}
 		})
 	}
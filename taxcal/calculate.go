@@ -0,0 +1,211 @@
+package taxcal
+
+import (
+	"github.com/windeesel365/assessment-tax/i18n"
+	"github.com/windeesel365/assessment-tax/money"
+)
+
+// TaxInput คือข้อมูลทั้งหมดที่ใช้คำนวนภาษีหนึ่งราย
+// TotalIncome คือเงินได้แบบก้อนเดียวที่ไม่หักค่าใช้จ่าย (รูปแบบเดิม) ส่วน Incomes แยกตามมาตรา 40
+type TaxInput struct {
+	TaxYear     int
+	TotalIncome money.Money
+	Incomes     []Income
+	WHT         money.Money
+	Allowances  []AllowanceClaim
+	Dependants  *Dependants
+	// FilingDate และ PaymentDate (optional) ใช้คำนวนเงินเพิ่มและค่าปรับเมื่อยื่นแบบหรือชำระเกินกำหนด
+	// PaymentDate เป็น nil คือชำระวันเดียวกับที่ยื่นแบบ
+	FilingDate  *Date
+	PaymentDate *Date
+	// Dividends คือเงินปันผลจากบริษัทไทยที่ถูกหักภาษี ณ ที่จ่าย 10% แล้ว
+	// DividendMethod ว่างคือเลือกวิธีที่เสียภาษีน้อยกว่าให้อัตโนมัติ
+	Dividends      []Dividend
+	DividendMethod string
+	// Severance (optional) คือเงินได้ก้อนเดียวที่ออกจากงาน ซึ่งแยกคำนวนภาษีต่างหาก แล้วรวมกับภาษีทั้งปี
+	Severance *Severance
+	// Trace (optional) บันทึกขั้นตอนการคำนวนตามลำดับ, nil คือไม่บันทึก
+	Trace *Trace
+	// Lang คือภาษาของ label ใน TaxLevels และ Rule ของ Trace, ว่างคือข้อความต้นฉบับ
+	Lang i18n.Lang
+}
+
+// TaxResult คือผลการคำนวนภาษีทั้งหมดของ Calculate
+type TaxResult struct {
+	TaxYear     int
+	GrossIncome money.Money
+	Incomes     []IncomeExpense
+	Expenses    money.Money
+	NetIncome   money.Money
+	Allowances  []AppliedAllowance
+	// NetIncomeCaps คือเพดานที่ใช้จริงของ AllowanceGroup แบบ NetIncomeCap เช่น เงินบริจาค
+	NetIncomeCaps    map[string]money.Money
+	FamilyAllowances []FamilyAllowance
+	TotalDeductions  money.Money
+	TaxableIncome    money.Money
+	Tax              money.Money
+	TaxPayable       money.Money
+	TaxRefund        money.Money
+	TaxLevels        []TaxLevel
+	// TaxMethod คือวิธีที่ใช้จริง, GrossIncomeTaxApplies เป็น true เมื่อต้องเทียบ 2 วิธี
+	TaxMethod             string
+	ProgressiveTax        money.Money
+	GrossIncomeTax        money.Money
+	GrossIncomeTaxApplies bool
+	// LateFiling เป็น nil เมื่อไม่ได้ระบุ FilingDate
+	LateFiling *LateFiling
+	// DividendTax เป็น nil เมื่อไม่มีเงินปันผล
+	DividendTax *DividendTax
+	// Severance เป็น nil เมื่อไม่มีเงินได้ก้อนเดียว, Tax รวมภาษีของ Severance แล้ว
+	Severance *SeveranceResult
+}
+
+// Calculate คำนวนภาษีตามลำดับ: หักค่าใช้จ่ายตามประเภทเงินได้ -> หักค่าลดหย่อน -> ขั้นบันใดภาษี
+// -> เทียบภาษีวิธีที่ 2 (ใช้วิธีที่สูงกว่า) -> wht
+// เมื่อมีเงินปันผล คำนวนทั้งแบบภาษีสุดท้าย 10% และแบบรวมคำนวนพร้อมเครดิตภาษี ดู calculateWithDividends
+// input ต้องผ่าน validityguard มาแล้ว
+func Calculate(in TaxInput) (TaxResult, error) {
+	in.Trace.setLang(in.Lang)
+	if len(in.Dividends) > 0 {
+		return calculateWithDividends(in)
+	}
+	return calculate(in)
+}
+
+// calculate คำนวนภาษีของเงินได้ทั้งหมดใน in โดยไม่สนใจ Dividends
+func calculate(in TaxInput) (TaxResult, error) {
+	brackets, err := TaxBracketsFor(in.TaxYear)
+	if err != nil {
+		return TaxResult{}, err
+	}
+	result := TaxResult{TaxYear: in.TaxYear}
+	if result.TaxYear == 0 {
+		result.TaxYear = DefaultTaxYear
+	}
+
+	// แปลงเงินได้สกุลต่างประเทศเป็นบาท แล้วหักค่าใช้จ่ายก่อนค่าลดหย่อน, TotalIncome เดิมไม่มีค่าใช้จ่าย
+	incomes, err := ConvertIncomes(in.Incomes, result.TaxYear)
+	if err != nil {
+		return TaxResult{}, err
+	}
+	result.Incomes, err = CalculateIncomeExpenses(incomes)
+	if err != nil {
+		return TaxResult{}, err
+	}
+	result.GrossIncome = in.TotalIncome
+	result.Expenses = money.Zero
+	for _, income := range result.Incomes {
+		result.GrossIncome = result.GrossIncome.Add(income.Amount)
+		result.Expenses = result.Expenses.Add(income.Expense)
+	}
+	result.NetIncome = result.GrossIncome.Sub(result.Expenses)
+	traceIncomes(in.Trace, result.Incomes)
+	in.Trace.Record(TraceStep{
+		Step:   TraceStepGrossIncome,
+		Input:  in.TotalIncome,
+		Rule:   in.Trace.rulef("totalIncome + %d income(s)", len(result.Incomes)),
+		Output: result.GrossIncome,
+	})
+	in.Trace.Record(TraceStep{
+		Step:   TraceStepNetIncome,
+		Input:  result.GrossIncome,
+		Rule:   in.Trace.rulef("less expenses %s", formatTraceAmount(result.Expenses)),
+		Output: result.NetIncome,
+	})
+
+	// ค่าลดหย่อนจาก allowance registry และค่าลดหย่อนครอบครัว
+	result.Allowances, err = ApplyAllowances(in.Allowances, result.GrossIncome)
+	if err != nil {
+		return TaxResult{}, err
+	}
+	result.FamilyAllowances = CalculateFamilyAllowances(in.Dependants)
+
+	// เงินบริจาคหักหลังสุด เพดาน 10% คิดจากเงินได้หลังหักค่าลดหย่อนอื่นทั้งหมด
+	familyTotal := money.Sum(FamilyAllowanceAmounts(result.FamilyAllowances)...)
+	result.NetIncomeCaps = ApplyNetIncomeCaps(result.Allowances, result.NetIncome.Sub(familyTotal))
+	traceAllowances(in.Trace, in.Allowances, result.Allowances, result.GrossIncome, result.NetIncomeCaps)
+	traceFamilyAllowances(in.Trace, result.FamilyAllowances)
+
+	deductions := append(AllowanceAmounts(result.Allowances), FamilyAllowanceAmounts(result.FamilyAllowances)...)
+	result.TotalDeductions = money.Sum(deductions...)
+
+	result.TaxableIncome = CaltaxableIncome(result.NetIncome, deductions...)
+	in.Trace.Record(TraceStep{
+		Step:   TraceStepTaxableIncome,
+		Input:  result.NetIncome,
+		Rule:   in.Trace.rulef("less deductions %s", formatTraceAmount(result.TotalDeductions)),
+		Output: result.TaxableIncome,
+	})
+	result.TaxLevels = CalculateTaxLevelDetails(result.TaxableIncome, brackets, in.Lang)
+	traceTaxBrackets(in.Trace, TraceStepTaxBracket, result.TaxableIncome, brackets, result.TaxLevels)
+	result.ProgressiveTax = money.Zero
+	for _, level := range result.TaxLevels {
+		result.ProgressiveTax = result.ProgressiveTax.Add(level.Tax)
+	}
+
+	// ผู้มีเงินได้ 40(2)-(8) ตั้งแต่ 120,000 บาท เสียภาษีวิธีที่สูงกว่า
+	result.Tax, result.TaxMethod = result.ProgressiveTax, TaxMethodProgressive
+	result.GrossIncomeTax, result.GrossIncomeTaxApplies = CalculateGrossIncomeTax(result.Incomes)
+	if result.GrossIncomeTaxApplies && result.GrossIncomeTax.GreaterThan(result.ProgressiveTax) {
+		result.Tax, result.TaxMethod = result.GrossIncomeTax, TaxMethodGrossIncome
+	}
+	if result.GrossIncomeTaxApplies {
+		in.Trace.Record(TraceStep{
+			Step:   TraceStepTaxMethod,
+			Name:   result.TaxMethod,
+			Input:  result.ProgressiveTax,
+			Rule:   in.Trace.rulef("higher of progressive tax and %s of 40(2)-40(8) income = %s", formatTraceRate(GrossIncomeTaxRate), formatTraceAmount(result.GrossIncomeTax)),
+			Output: result.Tax,
+		})
+	}
+
+	// เงินได้ก้อนเดียวที่ออกจากงานคำนวนแยก แล้วรวมภาษีเข้ากับภาษีทั้งปีก่อนหัก wht
+	if in.Severance != nil {
+		severance, err := CalculateSeverance(*in.Severance, result.TaxYear, in.Lang)
+		if err != nil {
+			return TaxResult{}, err
+		}
+		result.Severance = &severance
+		traceSeverance(in.Trace, *in.Severance, severance, brackets)
+		in.Trace.Record(TraceStep{
+			Step:   TraceStepSeverance,
+			Name:   "tax",
+			Input:  result.Tax,
+			Rule:   in.Trace.rulef("add severance tax %s", formatTraceAmount(severance.Tax)),
+			Output: result.Tax.Add(severance.Tax),
+		})
+		result.Tax = result.Tax.Add(severance.Tax)
+	}
+
+	result.TaxPayable, result.TaxRefund = splitPayableAndRefund(result.Tax, in.WHT)
+	in.Trace.Record(TraceStep{
+		Step:   TraceStepWHT,
+		Input:  result.Tax,
+		Rule:   in.Trace.rulef("less wht %s, negative is taxRefund", formatTraceAmount(in.WHT)),
+		Output: result.Tax.Sub(in.WHT),
+	})
+
+	// เงินเพิ่มและค่าปรับคิดเพิ่มจากภาษีที่ต้องชำระ
+	if in.FilingDate != nil {
+		paymentDate := *in.FilingDate
+		if in.PaymentDate != nil {
+			paymentDate = *in.PaymentDate
+		}
+		taxPayable := result.TaxPayable
+		lateFiling := CalculateLateFiling(result.TaxYear, taxPayable, *in.FilingDate, paymentDate)
+		// ค่าปรับหักกับเงินที่ได้คืนก่อน ภาษีที่ต้องชำระจึงรวมเงินเพิ่มและค่าปรับแล้ว
+		result.TaxPayable, result.TaxRefund = splitPayableAndRefund(
+			result.Tax.Add(lateFiling.Surcharge).Add(lateFiling.Penalty), in.WHT)
+		lateFiling.TotalPayable = result.TaxPayable
+		result.LateFiling = &lateFiling
+		in.Trace.Record(TraceStep{
+			Step:  TraceStepLateFiling,
+			Input: taxPayable,
+			Rule: in.Trace.rulef("surcharge %s x %d month(s) = %s, penalty %s",
+				formatTraceRate(SurchargeMonthlyRate), lateFiling.MonthsLate, formatTraceAmount(lateFiling.Surcharge), formatTraceAmount(lateFiling.Penalty)),
+			Output: lateFiling.TotalPayable,
+		})
+	}
+
+	return result, nil
+}
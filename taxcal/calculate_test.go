@@ -0,0 +1,149 @@
+package taxcal
+
+import (
+	"testing"
+
+	"github.com/windeesel365/assessment-tax/money"
+)
+
+func TestCalculate(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         TaxInput
+		taxableIncome money.Money
+		taxPayable    money.Money
+		taxRefund     money.Money
+	}{
+		{
+			name: "EXP01 total income only",
+			input: TaxInput{
+				TotalIncome: money.NewFromInt(500000),
+				Allowances:  []AllowanceClaim{{AllowanceType: "donation", Amount: money.Zero}},
+			},
+			taxableIncome: money.NewFromInt(440000),
+			taxPayable:    money.NewFromInt(29000),
+			taxRefund:     money.Zero,
+		},
+		{
+			name: "Personal claim within limit keeps initial exemption",
+			input: TaxInput{
+				TotalIncome: money.NewFromInt(500000),
+				Allowances:  []AllowanceClaim{{AllowanceType: "personal", Amount: money.NewFromInt(70000)}},
+			},
+			taxableIncome: money.NewFromInt(440000),
+			taxPayable:    money.NewFromInt(29000),
+			taxRefund:     money.Zero,
+		},
+		{
+			name: "EXP07 donation and k-receipt capped",
+			input: TaxInput{
+				TotalIncome: money.NewFromInt(500000),
+				Allowances: []AllowanceClaim{
+					{AllowanceType: "k-receipt", Amount: money.NewFromInt(200000)},
+					{AllowanceType: "donation", Amount: money.NewFromInt(100000)},
+				},
+			},
+			// เงินบริจาคไม่เกิน 10% ของ 500,000 - 60,000 - 50,000 = 39,000
+			taxableIncome: money.NewFromInt(351000),
+			taxPayable:    money.NewFromInt(20100),
+			taxRefund:     money.Zero,
+		},
+		{
+			name: "WHT refund",
+			input: TaxInput{
+				TotalIncome: money.NewFromInt(500000),
+				WHT:         money.NewFromInt(40000),
+			},
+			taxableIncome: money.NewFromInt(440000),
+			taxPayable:    money.Zero,
+			taxRefund:     money.NewFromInt(11000),
+		},
+		{
+			name: "Section 40 incomes with family allowances",
+			input: TaxInput{
+				Incomes: []Income{
+					{Section: "40(1)", Amount: money.NewFromInt(600000)},
+					{Section: "40(5)", SubType: "building", Amount: money.NewFromInt(100000)},
+				},
+				Dependants: &Dependants{Spouse: &Spouse{}},
+			},
+			// 700,000 - 100,000 - 30,000 - 60,000 - 60,000
+			taxableIncome: money.NewFromInt(450000),
+			taxPayable:    money.NewFromInt(30000),
+			taxRefund:     money.Zero,
+		},
+		{
+			name: "Earlier tax year",
+			input: TaxInput{
+				TaxYear:     2566,
+				TotalIncome: money.NewFromInt(500000),
+			},
+			taxableIncome: money.NewFromInt(440000),
+			taxPayable:    money.NewFromInt(21500),
+			taxRefund:     money.Zero,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Calculate(tt.input)
+			if err != nil {
+				t.Fatalf("Calculate() error = %v", err)
+			}
+			if !result.TaxableIncome.Equal(tt.taxableIncome) {
+				t.Errorf("Calculate() TaxableIncome = %v, want %v", result.TaxableIncome, tt.taxableIncome)
+			}
+			if !result.TaxPayable.Equal(tt.taxPayable) || !result.TaxRefund.Equal(tt.taxRefund) {
+				t.Errorf("Calculate() = %v, %v; want %v, %v", result.TaxPayable, result.TaxRefund, tt.taxPayable, tt.taxRefund)
+			}
+		})
+	}
+}
+
+func TestCalculateUnsupportedYear(t *testing.T) {
+	if _, err := Calculate(TaxInput{TaxYear: 2500}); err == nil {
+		t.Error("Calculate() with unsupported tax year did not return error")
+	}
+}
+
+func TestCalculateTaxMethod(t *testing.T) {
+	tests := []struct {
+		name       string
+		incomes    []Income
+		wantMethod string
+		wantTax    money.Money
+	}{
+		{
+			name:       "Salary uses progressive only",
+			incomes:    []Income{{Section: "40(1)", Amount: money.NewFromInt(600000)}},
+			wantMethod: TaxMethodProgressive,
+			wantTax:    money.NewFromInt(29000),
+		},
+		{
+			// 3,000,000 - 3,000,000 actual expenses - 60,000 = 0 progressive, 0.5% = 15,000
+			name:       "Business at break even uses gross income",
+			incomes:    []Income{{Section: "40(8)", Amount: money.NewFromInt(3000000), ExpenseMethod: ExpenseMethodActual, ActualExpenses: money.NewFromInt(3000000)}},
+			wantMethod: TaxMethodGrossIncome,
+			wantTax:    money.NewFromInt(15000),
+		},
+		{
+			// 3,000,000 - 1,800,000 - 60,000 = 1,140,000 -> 138,000 progressive, 0.5% = 15,000
+			name:       "Profitable business uses progressive",
+			incomes:    []Income{{Section: "40(8)", Amount: money.NewFromInt(3000000)}},
+			wantMethod: TaxMethodProgressive,
+			wantTax:    money.NewFromInt(138000),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Calculate(TaxInput{Incomes: tt.incomes})
+			if err != nil {
+				t.Fatalf("Calculate() error = %v", err)
+			}
+			if result.TaxMethod != tt.wantMethod || !result.Tax.Equal(tt.wantTax) {
+				t.Errorf("Calculate() = %s %v, want %s %v", result.TaxMethod, result.Tax, tt.wantMethod, tt.wantTax)
+			}
+		})
+	}
+}
@@ -1,19 +1,13 @@
 package taxcal
 
-// func calculate tax ตาม tax brackets
-// รองรับแค่ปีเดียวคือ 2567 (2024)
-// อัตราภาษีไม่มีการเปลี่ยนแปลงในอนาคต
-func calculateTax(taxableIncome float64) float64 {
-	switch {
-	case taxableIncome <= 150000:
-		return 0
-	case taxableIncome <= 500000:
-		return (taxableIncome - 150000) * 0.1
-	case taxableIncome <= 1000000:
-		return 35000 + (taxableIncome-500000)*0.15
-	case taxableIncome <= 2000000:
-		return 110000 + (taxableIncome-1000000)*0.2
-	default:
-		return 310000 + (taxableIncome-2000000)*0.35
+import "github.com/windeesel365/assessment-tax/money"
+
+// func calculate tax ตาม tax brackets ของปีภาษีที่เลือก
+// tax รวมได้จากผลรวมของ taxLevel เพื่อให้ทั้งสองค่ามาจากตารางเดียวกันเสมอ
+func calculateTax(taxableIncome money.Money, brackets []TaxBracket) money.Money {
+	tax := money.Zero
+	for _, level := range CalculateTaxLevelDetails(taxableIncome, brackets, "") {
+		tax = tax.Add(level.Tax)
 	}
+	return tax
 }
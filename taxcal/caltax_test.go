@@ -1,34 +1,65 @@
 package taxcal
 
 import (
-	"math"
 	"testing"
+
+	"github.com/windeesel365/assessment-tax/money"
 )
 
 func TestCalculateTax(t *testing.T) {
 	tests := []struct {
 		name          string
-		taxableIncome float64
-		expectedTax   float64
+		taxableIncome money.Money
+		expectedTax   money.Money
 	}{
-		{"No tax", 150000, 0},
-		{"Lowest taxable", 150001, 0.1},
-		{"Middle of first bracket", 300000, 15000},
-		{"End of first bracket", 500000, 35000},
-		{"Start of second bracket", 500001, 35000.15},
-		{"Middle of second bracket", 750000, 72500},
-		{"End of second bracket", 1000000, 110000},
-		{"Start of third bracket", 1000001, 110000.2},
-		{"Middle of third bracket", 1500000, 210000},
-		{"End of third bracket", 2000000, 310000},
-		{"Beyond third bracket", 2500000, 485000},
+		{"No tax", money.New(150000), money.New(0)},
+		{"Lowest taxable", money.New(150001), money.New(0.1)},
+		{"Middle of first bracket", money.New(300000), money.New(15000)},
+		{"End of first bracket", money.New(500000), money.New(35000)},
+		{"Start of second bracket", money.New(500001), money.New(35000.15)},
+		{"Middle of second bracket", money.New(750000), money.New(72500)},
+		{"End of second bracket", money.New(1000000), money.New(110000)},
+		{"Start of third bracket", money.New(1000001), money.New(110000.2)},
+		{"Middle of third bracket", money.New(1500000), money.New(210000)},
+		{"End of third bracket", money.New(2000000), money.New(310000)},
+		{"Beyond third bracket", money.New(2500000), money.New(485000)},
+	}
+
+	brackets, err := TaxBracketsFor(2567)
+	if err != nil {
+		t.Fatal(err)
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if tax := calculateTax(tt.taxableIncome); math.Abs(tax-tt.expectedTax) > 0.01 {
+			if tax := calculateTax(tt.taxableIncome, brackets); !tax.Equal(tt.expectedTax) {
 				t.Errorf("calculateTax(%v) = %v, want %v", tt.taxableIncome, tax, tt.expectedTax)
 			}
 		})
 	}
 }
+
+func TestCalculateTaxByYear(t *testing.T) {
+	tests := []struct {
+		name          string
+		taxYear       int
+		taxableIncome money.Money
+		expectedTax   money.Money
+	}{
+		{"2567 middle bracket", 2567, money.New(750000), money.New(72500)},
+		{"2566 middle bracket", 2566, money.New(750000), money.New(65000)},
+		{"2566 top bracket", 2566, money.New(6000000), money.New(1615000)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			brackets, err := TaxBracketsFor(tt.taxYear)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tax := calculateTax(tt.taxableIncome, brackets); !tax.Equal(tt.expectedTax) {
+				t.Errorf("calculateTax(%v) for %d = %v, want %v", tt.taxableIncome, tt.taxYear, tax, tt.expectedTax)
+			}
+		})
+	}
+}
@@ -1,9 +1,12 @@
 package taxcal
 
-func CaltaxableIncome(TotalIncome, personalExemption, donations, kReceipts float64) float64 {
-	taxableIncome := TotalIncome - (personalExemption + donations + kReceipts)
-	if taxableIncome < 0 {
-		taxableIncome = 0
+import "github.com/windeesel365/assessment-tax/money"
+
+// CaltaxableIncome หัก allowances ทุกชนิดออกจาก TotalIncome (ไม่ติดลบ)
+func CaltaxableIncome(TotalIncome money.Money, allowances ...money.Money) money.Money {
+	taxableIncome := TotalIncome.Sub(money.Sum(allowances...))
+	if taxableIncome.IsNegative() {
+		taxableIncome = money.Zero
 	}
 	return taxableIncome
 }
@@ -2,54 +2,56 @@ package taxcal
 
 import (
 	"testing"
+
+	"github.com/windeesel365/assessment-tax/money"
 )
 
 func TestCaltaxableIncome(t *testing.T) {
 	tests := []struct {
 		name              string
-		TotalIncome       float64
-		personalExemption float64
-		donations         float64
-		kReceipts         float64
-		want              float64
+		TotalIncome       money.Money
+		personalExemption money.Money
+		donations         money.Money
+		kReceipts         money.Money
+		want              money.Money
 	}{
 		{
 			name:              "Standard case",
-			TotalIncome:       100000,
-			personalExemption: 10000,
-			donations:         5000,
-			kReceipts:         2000,
-			want:              83000,
+			TotalIncome:       money.New(100000),
+			personalExemption: money.New(10000),
+			donations:         money.New(5000),
+			kReceipts:         money.New(2000),
+			want:              money.New(83000),
 		},
 		{
 			name:              "Zero taxable income",
-			TotalIncome:       0,
-			personalExemption: 0,
-			donations:         0,
-			kReceipts:         0,
-			want:              0,
+			TotalIncome:       money.New(0),
+			personalExemption: money.New(0),
+			donations:         money.New(0),
+			kReceipts:         money.New(0),
+			want:              money.New(0),
 		},
 		{
 			name:              "Negative result",
-			TotalIncome:       5000,
-			personalExemption: 10000,
-			donations:         1000,
-			kReceipts:         500,
-			want:              0,
+			TotalIncome:       money.New(5000),
+			personalExemption: money.New(10000),
+			donations:         money.New(1000),
+			kReceipts:         money.New(500),
+			want:              money.New(0),
 		},
 		{
 			name:              "All zero except income",
-			TotalIncome:       30000,
-			personalExemption: 0,
-			donations:         0,
-			kReceipts:         0,
-			want:              30000,
+			TotalIncome:       money.New(30000),
+			personalExemption: money.New(0),
+			donations:         money.New(0),
+			kReceipts:         money.New(0),
+			want:              money.New(30000),
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := CaltaxableIncome(tt.TotalIncome, tt.personalExemption, tt.donations, tt.kReceipts); got != tt.want {
+			if got := CaltaxableIncome(tt.TotalIncome, tt.personalExemption, tt.donations, tt.kReceipts); !got.Equal(tt.want) {
 				t.Errorf("CaltaxableIncome() = %v, want %v", got, tt.want)
 			}
 		})
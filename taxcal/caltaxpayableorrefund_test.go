@@ -2,26 +2,33 @@ package taxcal
 
 import (
 	"testing"
+
+	"github.com/windeesel365/assessment-tax/money"
 )
 
 func TestCalculateTaxPayableAndRefund(t *testing.T) {
 	tests := []struct {
 		name            string
-		taxableIncome   float64
-		wht             float64
-		expectedPayable CustomFloat64
-		expectedRefund  CustomFloat64
+		taxableIncome   money.Money
+		wht             money.Money
+		expectedPayable money.Money
+		expectedRefund  money.Money
 	}{
-		{"Exact payable as WHT", 500000, 35000, 0, 0},
-		{"WHT more than tax", 500000, 40000, 0, 5000},
-		{"WHT less than tax", 500000, 30000, 5000, 0},
-		{"No WHT", 500000, 0, 35000, 0},
+		{"Exact payable as WHT", money.New(500000), money.New(35000), money.New(0), money.New(0)},
+		{"WHT more than tax", money.New(500000), money.New(40000), money.New(0), money.New(5000)},
+		{"WHT less than tax", money.New(500000), money.New(30000), money.New(5000), money.New(0)},
+		{"No WHT", money.New(500000), money.New(0), money.New(35000), money.New(0)},
+	}
+
+	brackets, err := TaxBracketsFor(2567)
+	if err != nil {
+		t.Fatal(err)
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			payable, refund := CalculateTaxPayableAndRefund(tt.taxableIncome, tt.wht)
-			if payable != tt.expectedPayable || refund != tt.expectedRefund {
+			payable, refund := CalculateTaxPayableAndRefund(tt.taxableIncome, tt.wht, brackets)
+			if !payable.Equal(tt.expectedPayable) || !refund.Equal(tt.expectedRefund) {
 				t.Errorf("calculateTaxPayableAndRefund(%v, %v) = %v, %v; want %v, %v",
 					tt.taxableIncome, tt.wht, payable, refund, tt.expectedPayable, tt.expectedRefund)
 			}
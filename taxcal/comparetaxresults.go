@@ -0,0 +1,41 @@
+package taxcal
+
+import "github.com/windeesel365/assessment-tax/money"
+
+// TaxResultDiff คือผลต่างของ scenario เทียบกับ baseline (scenario - baseline)
+// ค่าติดลบคือ scenario เสียภาษีหรือมีเงินได้สุทธิน้อยกว่า baseline
+type TaxResultDiff struct {
+	Tax             money.Money `json:"tax"`
+	TaxPayable      money.Money `json:"taxPayable"`
+	TaxRefund       money.Money `json:"taxRefund"`
+	TaxableIncome   money.Money `json:"taxableIncome"`
+	TotalDeductions money.Money `json:"totalDeductions"`
+	TaxLevels       []TaxLevel  `json:"taxLevel"`
+}
+
+// CompareTaxResults หาผลต่างของ scenario เทียบกับ baseline
+// taxLevel จับคู่ตามชื่อขั้น ขั้นที่ baseline ไม่มี (ต่างปีภาษี) เทียบกับ 0
+func CompareTaxResults(baseline, scenario TaxResult) TaxResultDiff {
+	baselineLevels := map[string]money.Money{}
+	for _, level := range baseline.TaxLevels {
+		baselineLevels[level.Level] = level.Tax
+	}
+
+	levels := make([]TaxLevel, 0, len(scenario.TaxLevels))
+	for _, level := range scenario.TaxLevels {
+		baselineTax, ok := baselineLevels[level.Level]
+		if !ok {
+			baselineTax = money.Zero
+		}
+		levels = append(levels, TaxLevel{Level: level.Level, Tax: level.Tax.Sub(baselineTax)})
+	}
+
+	return TaxResultDiff{
+		Tax:             scenario.Tax.Sub(baseline.Tax),
+		TaxPayable:      scenario.TaxPayable.Sub(baseline.TaxPayable),
+		TaxRefund:       scenario.TaxRefund.Sub(baseline.TaxRefund),
+		TaxableIncome:   scenario.TaxableIncome.Sub(baseline.TaxableIncome),
+		TotalDeductions: scenario.TotalDeductions.Sub(baseline.TotalDeductions),
+		TaxLevels:       levels,
+	}
+}
@@ -0,0 +1,62 @@
+package taxcal
+
+import (
+	"testing"
+
+	"github.com/windeesel365/assessment-tax/money"
+)
+
+func TestCompareTaxResults(t *testing.T) {
+	calculate := func(in TaxInput) TaxResult {
+		t.Helper()
+		result, err := Calculate(in)
+		if err != nil {
+			t.Fatalf("Calculate() error = %v", err)
+		}
+		return result
+	}
+
+	baseline := calculate(TaxInput{TotalIncome: money.NewFromInt(1200000)})
+	withSSF := calculate(TaxInput{
+		TotalIncome: money.NewFromInt(1200000),
+		Allowances:  []AllowanceClaim{{AllowanceType: "ssf", Amount: money.NewFromInt(200000)}},
+	})
+
+	got := CompareTaxResults(baseline, withSSF)
+
+	// 1,140,000 -> 940,000: ขั้น 20% ลด 140,000 x 20% และขั้น 15% ลด 60,000 x 15%
+	tests := []struct {
+		name string
+		got  money.Money
+		want money.Money
+	}{
+		{"Tax", got.Tax, money.NewFromInt(-37000)},
+		{"TaxPayable", got.TaxPayable, money.NewFromInt(-37000)},
+		{"TaxRefund", got.TaxRefund, money.Zero},
+		{"TaxableIncome", got.TaxableIncome, money.NewFromInt(-200000)},
+		{"TotalDeductions", got.TotalDeductions, money.NewFromInt(200000)},
+		{"TaxLevel 500,001-1,000,000", got.TaxLevels[2].Tax, money.NewFromInt(-9000)},
+		{"TaxLevel 1,000,001-2,000,000", got.TaxLevels[3].Tax, money.NewFromInt(-28000)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.got.Equal(tt.want) {
+				t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompareTaxResultsUnmatchedLevel(t *testing.T) {
+	baseline := TaxResult{TaxLevels: []TaxLevel{{Level: "0-150,000", Tax: money.Zero}}}
+	scenario := TaxResult{TaxLevels: []TaxLevel{
+		{Level: "0-150,000", Tax: money.Zero},
+		{Level: "150,001-300,000", Tax: money.NewFromInt(7500)},
+	}}
+
+	got := CompareTaxResults(baseline, scenario)
+	if want := money.NewFromInt(7500); !got.TaxLevels[1].Tax.Equal(want) {
+		t.Errorf("TaxLevels[1].Tax = %v, want %v", got.TaxLevels[1].Tax, want)
+	}
+}
@@ -0,0 +1,90 @@
+package taxcal
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/windeesel365/assessment-tax/fxrates"
+	"github.com/windeesel365/assessment-tax/i18n"
+	"github.com/windeesel365/assessment-tax/money"
+)
+
+// CurrencyConversion คือการแปลงเงินได้สกุลต่างประเทศเป็นบาท
+// RateDate เป็น nil เมื่อใช้อัตราเฉลี่ยทั้งปีภาษี
+type CurrencyConversion struct {
+	Currency       string
+	Amount         money.Money
+	ActualExpenses money.Money
+	Rate           decimal.Decimal
+	RateDate       *Date
+}
+
+// MarshalJSON แสดง rate เป็นตัวเลข
+func (c CurrencyConversion) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Currency       string       `json:"currency"`
+		Amount         money.Money  `json:"amount"`
+		ActualExpenses *money.Money `json:"actualExpenses,omitempty"`
+		Rate           json.Number  `json:"rate"`
+		RateDate       *Date        `json:"rateDate,omitempty"`
+	}{
+		Currency:       c.Currency,
+		Amount:         c.Amount,
+		ActualExpenses: nonZero(c.ActualExpenses),
+		Rate:           json.Number(c.Rate.String()),
+		RateDate:       c.RateDate,
+	})
+}
+
+// nonZero คืน nil เมื่อ amount เป็น 0 สำหรับ field ที่ omitempty
+func nonZero(amount money.Money) *money.Money {
+	if amount.IsZero() {
+		return nil
+	}
+	return &amount
+}
+
+// ConvertIncomes แปลงเงินได้ที่มี currency เป็นบาท ด้วยตารางอัตราแลกเปลี่ยนของ fxrates
+// ใช้อัตราของวันที่ date ถ้ามี ไม่เช่นนั้นใช้อัตราเฉลี่ยของปีภาษี, ปัดเศษเป็นสตางค์
+// คืน slice ใหม่ โดยไม่แก้ incomes เดิม
+func ConvertIncomes(incomes []Income, taxYear int) ([]Income, error) {
+	if taxYear == 0 {
+		taxYear = DefaultTaxYear
+	}
+
+	converted := make([]Income, 0, len(incomes))
+	for i, income := range incomes {
+		if income.Currency == "" || income.Currency == fxrates.BaseCurrency {
+			converted = append(converted, income)
+			continue
+		}
+
+		var date *time.Time
+		if income.Date != nil {
+			date = &income.Date.Time
+		}
+		rate, err := fxrates.Lookup(income.Currency, taxYear, date)
+		if err != nil {
+			return nil, i18n.Errorf("incomes[%d]: %w", i, err)
+		}
+
+		conversion := &CurrencyConversion{
+			Currency:       income.Currency,
+			Amount:         income.Amount,
+			ActualExpenses: income.ActualExpenses,
+			Rate:           rate.Rate,
+		}
+		if rate.Date != nil {
+			conversion.RateDate = &Date{*rate.Date}
+		}
+
+		income.Amount = income.Amount.Mul(rate.Rate).Round(2)
+		income.ActualExpenses = income.ActualExpenses.Mul(rate.Rate).Round(2)
+		income.Currency = fxrates.BaseCurrency
+		income.Conversion = conversion
+		converted = append(converted, income)
+	}
+
+	return converted, nil
+}
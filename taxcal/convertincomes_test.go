@@ -0,0 +1,59 @@
+package taxcal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/windeesel365/assessment-tax/fxrates"
+	"github.com/windeesel365/assessment-tax/money"
+)
+
+func TestConvertIncomes(t *testing.T) {
+	saved := fxrates.All()
+	defer fxrates.Load(saved)
+
+	day := time.Date(2024, time.June, 14, 0, 0, 0, 0, time.UTC)
+	fxrates.Load([]fxrates.Rate{
+		{Currency: "USD", TaxYear: 2567, Rate: decimal.RequireFromString("35.5")},
+		{Currency: "USD", TaxYear: 2567, Date: &day, Rate: decimal.RequireFromString("36.123")},
+	})
+	rateDate := Date{day}
+
+	tests := []struct {
+		name     string
+		income   Income
+		want     money.Money
+		wantRate string
+		wantErr  bool
+	}{
+		{"Baht income unchanged", Income{Section: "40(1)", Amount: money.NewFromInt(1000)}, money.NewFromInt(1000), "", false},
+		{"Year average rate", Income{Section: "40(2)", Amount: money.NewFromInt(1000), Currency: "USD"}, money.NewFromInt(35500), "35.5", false},
+		{"Daily rate rounded to satang", Income{Section: "40(2)", Amount: money.New(10.5), Currency: "USD", Date: &rateDate}, money.New(379.29), "36.123", false},
+		{"No rate", Income{Section: "40(2)", Amount: money.NewFromInt(1000), Currency: "EUR"}, money.Zero, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ConvertIncomes([]Income{tt.income}, 2567)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ConvertIncomes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !got[0].Amount.Equal(tt.want) {
+				t.Errorf("ConvertIncomes() amount = %v, want %v", got[0].Amount, tt.want)
+			}
+			if tt.wantRate == "" {
+				if got[0].Conversion != nil {
+					t.Errorf("ConvertIncomes() conversion = %+v, want nil", got[0].Conversion)
+				}
+				return
+			}
+			if got[0].Conversion == nil || !got[0].Conversion.Rate.Equal(decimal.RequireFromString(tt.wantRate)) || !got[0].Conversion.Amount.Equal(tt.income.Amount) {
+				t.Errorf("ConvertIncomes() conversion = %+v, want rate %s", got[0].Conversion, tt.wantRate)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package taxcal
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/windeesel365/assessment-tax/i18n"
+)
+
+// DateLayout คือรูปแบบวันที่ที่รับและส่งใน JSON
+const DateLayout = "2006-01-02"
+
+// buddhistEraOffset ปี พ.ศ. - ปี ค.ศ.
+const buddhistEraOffset = 543
+
+// GregorianYear แปลงปีภาษี (พ.ศ.) เป็นปี ค.ศ., 0 คือ DefaultTaxYear
+func GregorianYear(taxYear int) int {
+	if taxYear == 0 {
+		taxYear = DefaultTaxYear
+	}
+	return taxYear - buddhistEraOffset
+}
+
+// Date คือวันที่ไม่มีเวลา รับส่งใน JSON เป็น string รูปแบบ "2006-01-02"
+type Date struct {
+	time.Time
+}
+
+// NewDate สร้าง Date จาก ปี (ค.ศ.) เดือน วัน
+func NewDate(year int, month time.Month, day int) Date {
+	return Date{time.Date(year, month, day, 0, 0, 0, 0, time.UTC)}
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.Format(DateLayout))
+}
+
+func (d *Date) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return i18n.Errorf("date must be a string in YYYY-MM-DD format")
+	}
+	t, err := time.Parse(DateLayout, s)
+	if err != nil {
+		return i18n.Errorf("date %q must be in YYYY-MM-DD format", s)
+	}
+	d.Time = t
+	return nil
+}
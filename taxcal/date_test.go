@@ -0,0 +1,41 @@
+package taxcal
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestDateJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Date
+		wantErr bool
+	}{
+		{"Valid date", `"2025-03-31"`, NewDate(2025, time.March, 31), false},
+		{"Invalid format", `"31/03/2025"`, Date{}, true},
+		{"Invalid day", `"2025-02-30"`, Date{}, true},
+		{"Not a string", `20250331`, Date{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Date
+			err := json.Unmarshal([]byte(tt.input), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UnmarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !got.Equal(tt.want.Time) {
+				t.Errorf("UnmarshalJSON() = %v, want %v", got, tt.want)
+			}
+			out, _ := json.Marshal(got)
+			if string(out) != tt.input {
+				t.Errorf("MarshalJSON() = %s, want %s", out, tt.input)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package taxcal
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/windeesel365/assessment-tax/i18n"
+	"github.com/windeesel365/assessment-tax/money"
+)
+
+// วิธีเสียภาษีเงินปันผล
+const (
+	// DividendMethodFinal ให้ภาษีที่ถูกหัก ณ ที่จ่าย 10% เป็นภาษีสุดท้าย ไม่นำมารวมคำนวน
+	DividendMethodFinal = "final"
+	// DividendMethodInclude รวมเงินปันผลและเครดิตภาษีเป็นเงินได้ 40(4) แล้วนำภาษีที่ถูกหักและเครดิตภาษีมาหักออก
+	DividendMethodInclude = "include"
+)
+
+// DividendWithholdingRate ภาษีหัก ณ ที่จ่ายของเงินปันผล
+var DividendWithholdingRate = money.Rate(0.10)
+
+// DefaultCorporateTaxRate อัตราภาษีเงินได้นิติบุคคล (%) ที่ใช้คำนวนเครดิตภาษี เมื่อไม่ได้ระบุ
+const DefaultCorporateTaxRate = 20
+
+// dividendSection เงินปันผลเป็นเงินได้ 40(4)(ข) ไม่มีค่าใช้จ่าย
+const dividendSection = "40(4)"
+
+// Dividend คือเงินปันผลหนึ่งรายการ
+// CorporateTaxRate คืออัตราภาษีนิติบุคคล (%) ที่บริษัทเสีย, nil คือ DefaultCorporateTaxRate และ 0 คือไม่มีเครดิตภาษี เช่น กิจการ BOI
+type Dividend struct {
+	Amount           money.Money `json:"amount"`
+	CorporateTaxRate *int        `json:"corporateTaxRate,omitempty"`
+}
+
+// DividendTax คือผลการเทียบภาษีเงินปันผล 2 วิธี
+// FinalTax และ IncludeTax คือภาระภาษีทั้งหมดของแต่ละวิธี (รวมภาษีที่ถูกหัก 10% และหักเครดิตภาษีแล้ว)
+type DividendTax struct {
+	Dividends   money.Money `json:"dividends"`
+	Withheld    money.Money `json:"withheld"`
+	TaxCredit   money.Money `json:"taxCredit"`
+	FinalTax    money.Money `json:"finalTax"`
+	IncludeTax  money.Money `json:"includeTax"`
+	Recommended string      `json:"recommended"`
+	Method      string      `json:"method"`
+}
+
+// corporateTaxRate คืนอัตราภาษีนิติบุคคลของเงินปันผล
+func (d Dividend) corporateTaxRate() int {
+	if d.CorporateTaxRate == nil {
+		return DefaultCorporateTaxRate
+	}
+	return *d.CorporateTaxRate
+}
+
+// TaxCredit เครดิตภาษีเงินปันผล = เงินปันผล x อัตราภาษีนิติบุคคล / (100 - อัตราภาษีนิติบุคคล) ปัดเศษเป็นสตางค์
+func (d Dividend) TaxCredit() money.Money {
+	rate := int64(d.corporateTaxRate())
+	return d.Amount.Mul(decimal.NewFromInt(rate).Div(decimal.NewFromInt(100 - rate))).Round(2)
+}
+
+// calculateWithDividends คำนวนภาษี 2 วิธีแล้วใช้วิธีตาม DividendMethod (ว่างคือวิธีที่ภาระภาษีน้อยกว่า)
+// วิธี final: ภาษีจากเงินได้อื่น + ภาษีที่ถูกหัก 10% ซึ่งนำมาขอคืนไม่ได้
+// วิธี include: รวมเงินปันผล + เครดิตภาษีเป็นเงินได้ 40(4), ภาษีที่ถูกหักและเครดิตภาษีนำมาหักเหมือน wht
+func calculateWithDividends(in TaxInput) (TaxResult, error) {
+	dividendTax := DividendTax{Dividends: money.Zero, Withheld: money.Zero, TaxCredit: money.Zero}
+	included := in
+	included.Incomes = append([]Income{}, in.Incomes...)
+	for _, dividend := range in.Dividends {
+		credit := dividend.TaxCredit()
+		dividendTax.Dividends = dividendTax.Dividends.Add(dividend.Amount)
+		dividendTax.Withheld = dividendTax.Withheld.Add(dividend.Amount.Mul(DividendWithholdingRate).Round(2))
+		dividendTax.TaxCredit = dividendTax.TaxCredit.Add(credit)
+		included.Incomes = append(included.Incomes, Income{Section: dividendSection, Amount: dividend.Amount.Add(credit)})
+	}
+	included.WHT = in.WHT.Add(dividendTax.Withheld).Add(dividendTax.TaxCredit)
+
+	// คำนวนทั้ง 2 วิธีด้วย Trace แยกกัน แล้วต่อเฉพาะขั้นตอนของวิธีที่เลือก
+	final := in
+	final.Trace = in.Trace.child()
+	included.Trace = in.Trace.child()
+
+	finalResult, err := calculate(final)
+	if err != nil {
+		return TaxResult{}, err
+	}
+	includeResult, err := calculate(included)
+	if err != nil {
+		return TaxResult{}, err
+	}
+
+	dividendTax.FinalTax = finalResult.Tax.Add(dividendTax.Withheld)
+	dividendTax.IncludeTax = includeResult.Tax.Sub(dividendTax.TaxCredit)
+	dividendTax.Recommended = DividendMethodFinal
+	if dividendTax.IncludeTax.LessThan(dividendTax.FinalTax) {
+		dividendTax.Recommended = DividendMethodInclude
+	}
+
+	dividendTax.Method = in.DividendMethod
+	if dividendTax.Method == "" {
+		dividendTax.Method = dividendTax.Recommended
+	}
+
+	var result TaxResult
+	var burden money.Money
+	switch dividendTax.Method {
+	case DividendMethodFinal:
+		result, burden = finalResult, dividendTax.FinalTax
+		in.Trace.append(final.Trace)
+	case DividendMethodInclude:
+		result, burden = includeResult, dividendTax.IncludeTax
+		in.Trace.append(included.Trace)
+	default:
+		return TaxResult{}, i18n.Errorf("dividendMethod must be %s or %s", DividendMethodFinal, DividendMethodInclude)
+	}
+	result.DividendTax = &dividendTax
+	in.Trace.Record(TraceStep{
+		Step:  TraceStepDividend,
+		Name:  dividendTax.Method,
+		Input: dividendTax.Dividends,
+		Rule: in.Trace.rulef("final %s withheld = %s, include with tax credit %s = %s, use %s",
+			formatTraceRate(DividendWithholdingRate), formatTraceAmount(dividendTax.FinalTax),
+			formatTraceAmount(dividendTax.TaxCredit), formatTraceAmount(dividendTax.IncludeTax), dividendTax.Method),
+		Output: burden,
+	})
+
+	return result, nil
+}
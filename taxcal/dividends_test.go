@@ -0,0 +1,133 @@
+package taxcal
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/windeesel365/assessment-tax/money"
+)
+
+func TestDividendTaxCredit(t *testing.T) {
+	rate := func(r int) *int { return &r }
+	tests := []struct {
+		name     string
+		dividend Dividend
+		want     money.Money
+	}{
+		{"Default corporate rate 20%", Dividend{Amount: money.NewFromInt(100000)}, money.NewFromInt(25000)},
+		{"Corporate rate 30%", Dividend{Amount: money.NewFromInt(70000), CorporateTaxRate: rate(30)}, money.NewFromInt(30000)},
+		{"Rounded to satang", Dividend{Amount: money.NewFromInt(1000), CorporateTaxRate: rate(23)}, money.NewFromDecimal(decimal.RequireFromString("298.70"))},
+		{"No credit", Dividend{Amount: money.NewFromInt(100000), CorporateTaxRate: rate(0)}, money.Zero},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.dividend.TaxCredit(); !got.Equal(tt.want) {
+				t.Errorf("TaxCredit() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalculateWithDividends(t *testing.T) {
+	noCredit := 0
+	tests := []struct {
+		name            string
+		input           TaxInput
+		wantFinal       money.Money
+		wantInclude     money.Money
+		wantRecommended string
+		wantMethod      string
+		wantPayable     money.Money
+		wantRefund      money.Money
+	}{
+		{
+			name: "Include is cheaper at 10% bracket",
+			input: TaxInput{
+				TotalIncome: money.NewFromInt(500000),
+				Dividends:   []Dividend{{Amount: money.NewFromInt(100000)}},
+			},
+			// 29,000 + 10,000 หัก ณ ที่จ่าย เทียบกับ ภาษีของ 565,000 = 44,750 - เครดิต 25,000
+			wantFinal:       money.NewFromInt(39000),
+			wantInclude:     money.NewFromInt(19750),
+			wantRecommended: DividendMethodInclude,
+			wantMethod:      DividendMethodInclude,
+			wantPayable:     money.NewFromInt(9750),
+			wantRefund:      money.Zero,
+		},
+		{
+			name: "Final is cheaper at 35% bracket",
+			input: TaxInput{
+				TotalIncome: money.NewFromInt(5000000),
+				Dividends:   []Dividend{{Amount: money.NewFromInt(100000)}},
+			},
+			wantFinal:       money.NewFromInt(1349000),
+			wantInclude:     money.NewFromInt(1357750),
+			wantRecommended: DividendMethodFinal,
+			wantMethod:      DividendMethodFinal,
+			wantPayable:     money.NewFromInt(1339000),
+			wantRefund:      money.Zero,
+		},
+		{
+			name: "Forced include at 35% bracket",
+			input: TaxInput{
+				TotalIncome:    money.NewFromInt(5000000),
+				Dividends:      []Dividend{{Amount: money.NewFromInt(100000)}},
+				DividendMethod: DividendMethodInclude,
+			},
+			wantFinal:       money.NewFromInt(1349000),
+			wantInclude:     money.NewFromInt(1357750),
+			wantRecommended: DividendMethodFinal,
+			wantMethod:      DividendMethodInclude,
+			wantPayable:     money.NewFromInt(1347750),
+			wantRefund:      money.Zero,
+		},
+		{
+			name: "No tax credit makes include refund only withholding",
+			input: TaxInput{
+				TotalIncome: money.NewFromInt(100000),
+				Dividends:   []Dividend{{Amount: money.NewFromInt(50000), CorporateTaxRate: &noCredit}},
+			},
+			// เงินได้รวมไม่ถึงขั้นเสียภาษี ขอคืนภาษีที่ถูกหัก 5,000 ได้ทั้งหมด
+			wantFinal:       money.NewFromInt(5000),
+			wantInclude:     money.Zero,
+			wantRecommended: DividendMethodInclude,
+			wantMethod:      DividendMethodInclude,
+			wantPayable:     money.Zero,
+			wantRefund:      money.NewFromInt(5000),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Calculate(tt.input)
+			if err != nil {
+				t.Fatalf("Calculate() error = %v", err)
+			}
+			got := result.DividendTax
+			if got == nil {
+				t.Fatal("DividendTax is nil")
+			}
+			if !got.FinalTax.Equal(tt.wantFinal) || !got.IncludeTax.Equal(tt.wantInclude) {
+				t.Errorf("FinalTax, IncludeTax = %v, %v; want %v, %v", got.FinalTax, got.IncludeTax, tt.wantFinal, tt.wantInclude)
+			}
+			if got.Recommended != tt.wantRecommended || got.Method != tt.wantMethod {
+				t.Errorf("Recommended, Method = %s, %s; want %s, %s", got.Recommended, got.Method, tt.wantRecommended, tt.wantMethod)
+			}
+			if !result.TaxPayable.Equal(tt.wantPayable) || !result.TaxRefund.Equal(tt.wantRefund) {
+				t.Errorf("TaxPayable, TaxRefund = %v, %v; want %v, %v", result.TaxPayable, result.TaxRefund, tt.wantPayable, tt.wantRefund)
+			}
+		})
+	}
+}
+
+func TestCalculateWithoutDividends(t *testing.T) {
+	result, err := Calculate(TaxInput{TotalIncome: money.NewFromInt(500000)})
+	if err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+	if result.DividendTax != nil {
+		t.Errorf("DividendTax = %+v, want nil", result.DividendTax)
+	}
+}
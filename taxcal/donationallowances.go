@@ -0,0 +1,68 @@
+package taxcal
+
+import (
+	"github.com/windeesel365/assessment-tax/i18n"
+	"github.com/windeesel365/assessment-tax/money"
+)
+
+// ชื่อเพดานรวมของเงินบริจาค
+const (
+	// เงินบริจาคเพื่อการศึกษา การกีฬา และโรงพยาบาลรัฐ หัก 2 เท่า รวมกันไม่เกิน 10% ของเงินได้หลังหักค่าลดหย่อนอื่น
+	DoubleDonationGroup = "double-donation"
+	// เงินบริจาคทั่วไป ไม่เกิน 10% ของเงินได้หลังหักค่าลดหย่อนอื่นและเงินบริจาค 2 เท่าแล้ว
+	GeneralDonationGroup = "general-donation"
+)
+
+// DonationIncomeRate คือสัดส่วนสูงสุดของเงินบริจาคต่อเงินได้หลังหักค่าลดหย่อนอื่น
+var DonationIncomeRate = money.Rate(0.10)
+
+// PoliticalDonationCap คือเพดานเงินบริจาคพรรคการเมือง ซึ่งแยกจากเพดาน 10%
+var PoliticalDonationCap = money.NewFromInt(10000)
+
+// donationIncomeCap เพดาน 10% ของเงินได้ที่เหลือ
+func donationIncomeCap(netIncome money.Money) money.Money {
+	return netIncome.Mul(DonationIncomeRate)
+}
+
+// donationAllowance กฎพื้นฐานของเงินบริจาคแต่ละประเภท
+func donationAllowance(name, label string) AllowanceRule {
+	return AllowanceRule{
+		Name:       name,
+		Min:        money.Zero,
+		MinMessage: i18n.Msg("The %s must not be negative. Please enter an amount of 0 THB or more and try again.", i18n.Text(label)),
+		Donation:   true,
+	}
+}
+
+// เงินบริจาคแยกประเภท, เงินบริจาคทั่วไป ("donation") ลงทะเบียนใน allowanceregistry.go
+// group 2 เท่าลงทะเบียนก่อน เพื่อให้หักก่อนเงินบริจาคทั่วไป
+func init() {
+	RegisterAllowanceGroup(AllowanceGroup{Name: DoubleDonationGroup, NetIncomeCap: donationIncomeCap})
+	RegisterAllowanceGroup(AllowanceGroup{Name: GeneralDonationGroup, NetIncomeCap: donationIncomeCap})
+
+	for _, donation := range []struct{ name, label string }{
+		{"donation-education", "education donation"},
+		{"donation-sports", "sports donation"},
+		{"donation-hospital", "public hospital donation"},
+	} {
+		rule := donationAllowance(donation.name, donation.label)
+		rule.Multiplier = 2
+		rule.Group = DoubleDonationGroup
+		RegisterAllowance(rule)
+	}
+
+	political := donationAllowance("donation-political", "political party donation")
+	political.Cap = func() money.Money { return PoliticalDonationCap }
+	RegisterAllowance(political)
+}
+
+// ClaimedDonations คืนเงินบริจาคที่ client ส่งมา พร้อมยอดที่หักได้จริง
+func ClaimedDonations(applied []AppliedAllowance) []AppliedAllowance {
+	donations := []AppliedAllowance{}
+	for i, rule := range allowanceRegistry {
+		if rule.Donation && applied[i].Claimed.IsPositive() {
+			donations = append(donations, applied[i])
+		}
+	}
+	return donations
+}
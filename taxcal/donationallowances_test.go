@@ -0,0 +1,87 @@
+package taxcal
+
+import (
+	"testing"
+
+	"github.com/windeesel365/assessment-tax/money"
+)
+
+func TestApplyNetIncomeCaps(t *testing.T) {
+	tests := []struct {
+		name   string
+		claims []AllowanceClaim
+		want   map[string]money.Money
+	}{
+		{
+			name: "General donation capped at 10%",
+			// 500,000 - 60,000 = 440,000 -> 44,000
+			claims: []AllowanceClaim{{AllowanceType: "donation", Amount: money.NewFromInt(200000)}},
+			want:   map[string]money.Money{"donation": money.NewFromInt(44000)},
+		},
+		{
+			name: "Education donation counts double",
+			claims: []AllowanceClaim{
+				{AllowanceType: "donation-education", Amount: money.NewFromInt(10000)},
+			},
+			want: map[string]money.Money{"donation-education": money.NewFromInt(20000)},
+		},
+		{
+			name: "Double donations share one cap before general donation",
+			// 2 เท่า: 60,000 รวมไม่เกิน 44,000, ทั่วไป: 10% ของ 440,000 - 44,000 = 39,600
+			claims: []AllowanceClaim{
+				{AllowanceType: "donation-education", Amount: money.NewFromInt(20000)},
+				{AllowanceType: "donation-hospital", Amount: money.NewFromInt(10000)},
+				{AllowanceType: "donation", Amount: money.NewFromInt(50000)},
+			},
+			want: map[string]money.Money{
+				"donation-education": money.NewFromInt(40000),
+				"donation-hospital":  money.NewFromInt(4000),
+				"donation":           money.NewFromInt(39600),
+			},
+		},
+		{
+			name: "Political donation has separate cap",
+			claims: []AllowanceClaim{
+				{AllowanceType: "donation-political", Amount: money.NewFromInt(50000)},
+				{AllowanceType: "donation", Amount: money.NewFromInt(50000)},
+			},
+			// 10% ของ 440,000 - 10,000 = 43,000
+			want: map[string]money.Money{
+				"donation-political": money.NewFromInt(10000),
+				"donation":           money.NewFromInt(43000),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			income := money.NewFromInt(500000)
+			applied, err := ApplyAllowances(tt.claims, income)
+			if err != nil {
+				t.Fatalf("ApplyAllowances() error = %v", err)
+			}
+			ApplyNetIncomeCaps(applied, income)
+			for _, allowance := range applied {
+				if want, ok := tt.want[allowance.AllowanceType]; ok && !allowance.Amount.Equal(want) {
+					t.Errorf("ApplyNetIncomeCaps() %s = %v, want %v", allowance.AllowanceType, allowance.Amount, want)
+				}
+			}
+		})
+	}
+}
+
+func TestClaimedDonations(t *testing.T) {
+	applied, err := ApplyAllowances([]AllowanceClaim{
+		{AllowanceType: "donation", Amount: money.Zero},
+		{AllowanceType: "donation-sports", Amount: money.NewFromInt(1000)},
+		{AllowanceType: "k-receipt", Amount: money.NewFromInt(1000)},
+	}, money.NewFromInt(500000))
+	if err != nil {
+		t.Fatalf("ApplyAllowances() error = %v", err)
+	}
+
+	got := ClaimedDonations(applied)
+	if len(got) != 1 || got[0].AllowanceType != "donation-sports" || !got[0].Amount.Equal(money.NewFromInt(2000)) {
+		t.Errorf("ClaimedDonations() = %+v, want only donation-sports 2000", got)
+	}
+}
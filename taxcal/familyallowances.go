@@ -0,0 +1,122 @@
+package taxcal
+
+import (
+	"sort"
+
+	"github.com/windeesel365/assessment-tax/money"
+)
+
+// ค่าลดหย่อนครอบครัวตามประมวลรัษฎากร
+var (
+	SpouseAllowance            = money.NewFromInt(60000)
+	ChildAllowance             = money.NewFromInt(30000)
+	ChildBornFrom2561Allowance = money.NewFromInt(60000) // บุตรคนที่ 2 เป็นต้นไปที่เกิดตั้งแต่ปี 2561
+	ParentCareAllowance        = money.NewFromInt(30000) // ต่อบิดามารดา 1 คน อายุ 60 ปีขึ้นไป
+	DisabledCareAllowance      = money.NewFromInt(60000) // ต่อผู้พิการหรือทุพพลภาพ 1 คน
+)
+
+// เงื่อนไขของค่าลดหย่อนครอบครัว ใช้ร่วมกับ validityguard
+const (
+	ChildAllowanceDoubleFromYear = 2561
+	ParentCareMinAge             = 60
+	MaxParentsForCare            = 4 // บิดามารดาของตนเองและของคู่สมรส
+)
+
+// ParentCareMaxIncome เงินได้สูงสุดของบิดามารดาที่ยังใช้สิทธิลดหย่อนได้
+var ParentCareMaxIncome = money.NewFromInt(30000)
+
+// Dependants คือข้อมูลครอบครัวที่ใช้หักค่าลดหย่อน
+type Dependants struct {
+	Spouse             *Spouse  `json:"spouse,omitempty"`
+	Children           []Child  `json:"children,omitempty"`
+	Parents            []Parent `json:"parents,omitempty"`
+	DisabledDependants int      `json:"disabledDependants,omitempty"`
+}
+
+// Spouse คู่สมรส ลดหย่อนได้เมื่อไม่มีเงินได้
+type Spouse struct {
+	HasIncome bool `json:"hasIncome"`
+}
+
+// Child บุตร ระบุปีเกิด (พ.ศ.)
+type Child struct {
+	BirthYear int `json:"birthYear"`
+}
+
+// Parent บิดามารดาที่อุปการะเลี้ยงดู
+type Parent struct {
+	Age    int         `json:"age"`
+	Income money.Money `json:"income"`
+}
+
+// FamilyAllowance คือยอดค่าลดหย่อนครอบครัวแต่ละชนิดที่หักได้
+type FamilyAllowance struct {
+	AllowanceType string      `json:"allowanceType"`
+	Count         int         `json:"count"`
+	Amount        money.Money `json:"amount"`
+}
+
+// CalculateFamilyAllowances หาค่าลดหย่อนคู่สมรส บุตร บิดามารดา และผู้พิการ
+// dependants ต้องผ่าน validityguard มาแล้ว, ชนิดที่ไม่ได้สิทธิจะไม่อยู่ในผลลัพธ์
+func CalculateFamilyAllowances(dependants *Dependants) []FamilyAllowance {
+	var allowances []FamilyAllowance
+	if dependants == nil {
+		return allowances
+	}
+
+	if dependants.Spouse != nil && !dependants.Spouse.HasIncome {
+		allowances = append(allowances, FamilyAllowance{AllowanceType: "spouse", Count: 1, Amount: SpouseAllowance})
+	}
+
+	if len(dependants.Children) > 0 {
+		// เรียงตามปีเกิด เพื่อหาบุตรคนแรก
+		birthYears := make([]int, 0, len(dependants.Children))
+		for _, child := range dependants.Children {
+			birthYears = append(birthYears, child.BirthYear)
+		}
+		sort.Ints(birthYears)
+
+		amount := money.Zero
+		for i, birthYear := range birthYears {
+			if i > 0 && birthYear >= ChildAllowanceDoubleFromYear {
+				amount = amount.Add(ChildBornFrom2561Allowance)
+			} else {
+				amount = amount.Add(ChildAllowance)
+			}
+		}
+		allowances = append(allowances, FamilyAllowance{AllowanceType: "child", Count: len(birthYears), Amount: amount})
+	}
+
+	eligibleParents := 0
+	for _, parent := range dependants.Parents {
+		if parent.Age >= ParentCareMinAge && parent.Income.LessThanOrEqual(ParentCareMaxIncome) {
+			eligibleParents++
+		}
+	}
+	if eligibleParents > 0 {
+		allowances = append(allowances, FamilyAllowance{
+			AllowanceType: "parent",
+			Count:         eligibleParents,
+			Amount:        ParentCareAllowance.MulInt(int64(eligibleParents)),
+		})
+	}
+
+	if dependants.DisabledDependants > 0 {
+		allowances = append(allowances, FamilyAllowance{
+			AllowanceType: "disabled-dependant",
+			Count:         dependants.DisabledDependants,
+			Amount:        DisabledCareAllowance.MulInt(int64(dependants.DisabledDependants)),
+		})
+	}
+
+	return allowances
+}
+
+// FamilyAllowanceAmounts คืนยอดที่หักได้ สำหรับส่งต่อให้ CaltaxableIncome
+func FamilyAllowanceAmounts(allowances []FamilyAllowance) []money.Money {
+	amounts := make([]money.Money, 0, len(allowances))
+	for _, allowance := range allowances {
+		amounts = append(amounts, allowance.Amount)
+	}
+	return amounts
+}
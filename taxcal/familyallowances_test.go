@@ -0,0 +1,74 @@
+package taxcal
+
+import (
+	"testing"
+
+	"github.com/windeesel365/assessment-tax/money"
+)
+
+func TestCalculateFamilyAllowances(t *testing.T) {
+	tests := []struct {
+		name       string
+		dependants *Dependants
+		want       map[string]money.Money
+	}{
+		{
+			name:       "No dependants",
+			dependants: nil,
+			want:       map[string]money.Money{},
+		},
+		{
+			name:       "Spouse without income",
+			dependants: &Dependants{Spouse: &Spouse{HasIncome: false}},
+			want:       map[string]money.Money{"spouse": money.NewFromInt(60000)},
+		},
+		{
+			name:       "Spouse with income",
+			dependants: &Dependants{Spouse: &Spouse{HasIncome: true}},
+			want:       map[string]money.Money{},
+		},
+		{
+			name: "Second child born from 2561",
+			dependants: &Dependants{Children: []Child{
+				{BirthYear: 2563}, {BirthYear: 2559}, {BirthYear: 2561},
+			}},
+			want: map[string]money.Money{"child": money.NewFromInt(150000)},
+		},
+		{
+			name: "Children born before 2561",
+			dependants: &Dependants{Children: []Child{
+				{BirthYear: 2555}, {BirthYear: 2558},
+			}},
+			want: map[string]money.Money{"child": money.NewFromInt(60000)},
+		},
+		{
+			name: "Parents and disabled dependants",
+			dependants: &Dependants{
+				Parents: []Parent{
+					{Age: 65, Income: money.Zero},
+					{Age: 62, Income: money.NewFromInt(40000)},
+					{Age: 70, Income: money.NewFromInt(30000)},
+				},
+				DisabledDependants: 2,
+			},
+			want: map[string]money.Money{
+				"parent":             money.NewFromInt(60000),
+				"disabled-dependant": money.NewFromInt(120000),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CalculateFamilyAllowances(tt.dependants)
+			if len(got) != len(tt.want) {
+				t.Fatalf("CalculateFamilyAllowances() = %v, want %v", got, tt.want)
+			}
+			for _, allowance := range got {
+				if want, ok := tt.want[allowance.AllowanceType]; !ok || !allowance.Amount.Equal(want) {
+					t.Errorf("CalculateFamilyAllowances() %s = %v, want %v", allowance.AllowanceType, allowance.Amount, want)
+				}
+			}
+		})
+	}
+}
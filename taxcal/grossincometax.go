@@ -0,0 +1,40 @@
+package taxcal
+
+import "github.com/windeesel365/assessment-tax/money"
+
+// วิธีคำนวนภาษีที่ใช้จริง
+const (
+	TaxMethodProgressive = "progressive"  // ขั้นบันใดภาษีจากเงินได้สุทธิ
+	TaxMethodGrossIncome = "gross-income" // 0.5% ของเงินได้พึงประเมิน
+)
+
+// เงื่อนไขภาษีวิธีที่ 2 ตามมาตรา 48(2)
+var (
+	GrossIncomeTaxRate      = money.Rate(0.005)
+	GrossIncomeTaxThreshold = money.NewFromInt(120000) // เงินได้ 40(2)-(8) ตั้งแต่จำนวนนี้ต้องเทียบ 2 วิธี
+	GrossIncomeTaxExemption = money.NewFromInt(5000)   // ภาษีวิธีที่ 2 ไม่เกินจำนวนนี้ได้รับยกเว้น
+)
+
+// salarySection เงินได้ 40(1) ไม่นับรวมในภาษีวิธีที่ 2
+const salarySection = "40(1)"
+
+// CalculateGrossIncomeTax หาภาษีวิธีที่ 2 จากเงินได้ 40(2)-(8)
+// applies เป็น false เมื่อเงินได้ไม่ถึง GrossIncomeTaxThreshold จึงใช้แค่ขั้นบันใดภาษี
+// totalIncome แบบเดิมถือเป็นเงินได้ 40(1) จึงไม่ได้ส่งมาที่นี่
+func CalculateGrossIncomeTax(incomes []IncomeExpense) (tax money.Money, applies bool) {
+	nonSalaryIncome := money.Zero
+	for _, income := range incomes {
+		if income.Section != salarySection {
+			nonSalaryIncome = nonSalaryIncome.Add(income.Amount)
+		}
+	}
+	if nonSalaryIncome.LessThan(GrossIncomeTaxThreshold) {
+		return money.Zero, false
+	}
+
+	tax = nonSalaryIncome.Mul(GrossIncomeTaxRate)
+	if tax.LessThanOrEqual(GrossIncomeTaxExemption) {
+		tax = money.Zero
+	}
+	return tax, true
+}
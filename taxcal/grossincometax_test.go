@@ -0,0 +1,54 @@
+package taxcal
+
+import (
+	"testing"
+
+	"github.com/windeesel365/assessment-tax/money"
+)
+
+func TestCalculateGrossIncomeTax(t *testing.T) {
+	tests := []struct {
+		name        string
+		incomes     []IncomeExpense
+		wantTax     money.Money
+		wantApplies bool
+	}{
+		{
+			name:        "Salary only",
+			incomes:     []IncomeExpense{{Section: "40(1)", Amount: money.NewFromInt(5000000)}},
+			wantTax:     money.Zero,
+			wantApplies: false,
+		},
+		{
+			name:        "Below threshold",
+			incomes:     []IncomeExpense{{Section: "40(8)", Amount: money.NewFromInt(119999)}},
+			wantTax:     money.Zero,
+			wantApplies: false,
+		},
+		{
+			name:        "Within exemption",
+			incomes:     []IncomeExpense{{Section: "40(8)", Amount: money.NewFromInt(1000000)}},
+			wantTax:     money.Zero,
+			wantApplies: true,
+		},
+		{
+			name: "Above exemption",
+			incomes: []IncomeExpense{
+				{Section: "40(1)", Amount: money.NewFromInt(500000)},
+				{Section: "40(8)", Amount: money.NewFromInt(2000000)},
+				{Section: "40(5)", Amount: money.NewFromInt(500000)},
+			},
+			wantTax:     money.NewFromInt(12500),
+			wantApplies: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tax, applies := CalculateGrossIncomeTax(tt.incomes)
+			if applies != tt.wantApplies || !tax.Equal(tt.wantTax) {
+				t.Errorf("CalculateGrossIncomeTax() = %v, %v; want %v, %v", tax, applies, tt.wantTax, tt.wantApplies)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package taxcal
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/windeesel365/assessment-tax/i18n"
+	"github.com/windeesel365/assessment-tax/money"
+)
+
+// วิธีหักค่าใช้จ่ายของเงินได้
+const (
+	ExpenseMethodFlat   = "flat"   // หักแบบเหมาตามอัตรา (ค่าเริ่มต้น)
+	ExpenseMethodActual = "actual" // หักตามจริงตาม actualExpenses
+)
+
+// กลุ่มเพดานค่าใช้จ่ายร่วม: เงินได้ 40(1) และ 40(2) หักรวมกันไม่เกิน 100,000 บาท
+const employmentExpenseGroup = "employment"
+
+// IncomeSection คือกฎการหักค่าใช้จ่ายของเงินได้ตามมาตรา 40 แต่ละประเภท
+type IncomeSection struct {
+	// Section เช่น "40(1)"
+	Section string
+	// Rates คืออัตราหักเหมาตาม subType, key "" คืออัตราเมื่อไม่ระบุ subType
+	Rates map[string]decimal.Decimal
+	// Cap คือเพดานค่าใช้จ่าย (nil คือไม่มีเพดาน)
+	Cap *money.Money
+	// CapGroup ใช้เพดาน Cap ร่วมกับ section อื่นในกลุ่มเดียวกัน
+	CapGroup string
+	// AllowsActual เป็น true เมื่อเลือกหักค่าใช้จ่ายตามจริงได้
+	AllowsActual bool
+}
+
+// HasSubType เช็คว่า subType ใช้กับ section นี้ได้
+func (s IncomeSection) HasSubType(subType string) bool {
+	_, ok := s.Rates[subType]
+	return ok
+}
+
+func capOf(amount int64) *money.Money {
+	limit := money.NewFromInt(amount)
+	return &limit
+}
+
+// incomeSections กฎการหักค่าใช้จ่ายตามประมวลรัษฎากรมาตรา 42 ทวิ - 43
+var incomeSections = []IncomeSection{
+	{Section: "40(1)", Rates: map[string]decimal.Decimal{"": money.Rate(0.5)}, Cap: capOf(100000), CapGroup: employmentExpenseGroup},
+	{Section: "40(2)", Rates: map[string]decimal.Decimal{"": money.Rate(0.5)}, Cap: capOf(100000), CapGroup: employmentExpenseGroup},
+	{Section: "40(3)", Rates: map[string]decimal.Decimal{"": money.Rate(0.5)}, Cap: capOf(100000)},
+	{Section: "40(4)", Rates: map[string]decimal.Decimal{"": money.Rate(0)}},
+	{Section: "40(5)", Rates: map[string]decimal.Decimal{
+		"":                  money.Rate(0.3),
+		"building":          money.Rate(0.3),
+		"agricultural-land": money.Rate(0.2),
+		"land":              money.Rate(0.15),
+		"vehicle":           money.Rate(0.3),
+		"other":             money.Rate(0.1),
+	}, AllowsActual: true},
+	{Section: "40(6)", Rates: map[string]decimal.Decimal{
+		"":        money.Rate(0.3),
+		"medical": money.Rate(0.6),
+		"other":   money.Rate(0.3),
+	}, AllowsActual: true},
+	{Section: "40(7)", Rates: map[string]decimal.Decimal{"": money.Rate(0.6)}, AllowsActual: true},
+	{Section: "40(8)", Rates: map[string]decimal.Decimal{"": money.Rate(0.6)}, AllowsActual: true},
+}
+
+// LookupIncomeSection หากฎของเงินได้ตาม section
+func LookupIncomeSection(section string) (IncomeSection, bool) {
+	for _, s := range incomeSections {
+		if s.Section == section {
+			return s, true
+		}
+	}
+	return IncomeSection{}, false
+}
+
+// IncomeSectionNames คืนชื่อ section ทั้งหมด
+func IncomeSectionNames() []string {
+	names := make([]string, 0, len(incomeSections))
+	for _, s := range incomeSections {
+		names = append(names, s.Section)
+	}
+	return names
+}
+
+// Income คือเงินได้หนึ่งรายการ แยกประเภทตามมาตรา 40
+type Income struct {
+	Section        string      `json:"section"`
+	SubType        string      `json:"subType,omitempty"`
+	Amount         money.Money `json:"amount"`
+	ExpenseMethod  string      `json:"expenseMethod,omitempty"`
+	ActualExpenses money.Money `json:"actualExpenses"`
+	// Currency (optional) คือสกุลเงินของ Amount และ ActualExpenses, Date (optional) คือวันที่ได้รับเงิน
+	// ใช้เลือกอัตราแลกเปลี่ยนรายวัน ไม่ระบุคือใช้อัตราเฉลี่ยทั้งปี
+	Currency string `json:"currency,omitempty"`
+	Date     *Date  `json:"date,omitempty"`
+	// Conversion ถูกกำหนดโดย ConvertIncomes เมื่อแปลงเป็นบาทแล้ว
+	Conversion *CurrencyConversion `json:"-"`
+}
+
+// IncomeExpense คือเงินได้หนึ่งรายการหลังหักค่าใช้จ่ายแล้ว
+type IncomeExpense struct {
+	Section   string      `json:"section"`
+	SubType   string      `json:"subType,omitempty"`
+	Amount    money.Money `json:"amount"`
+	Expense   money.Money `json:"expense"`
+	NetIncome money.Money `json:"netIncome"`
+	// Conversion คือยอดสกุลเดิมและอัตราแลกเปลี่ยนที่ใช้ (nil คือเงินบาท)
+	Conversion *CurrencyConversion `json:"conversion,omitempty"`
+}
+
+// CalculateIncomeExpenses หักค่าใช้จ่ายของเงินได้แต่ละรายการตามกฎของ section
+// เงินได้สกุลต่างประเทศต้องผ่าน ConvertIncomes ก่อน
+// รายการที่อยู่ CapGroup เดียวกันใช้เพดานร่วมกันตามลำดับที่ส่งมา
+func CalculateIncomeExpenses(incomes []Income) ([]IncomeExpense, error) {
+	results := make([]IncomeExpense, 0, len(incomes))
+	remainingCaps := map[string]money.Money{}
+
+	for _, income := range incomes {
+		section, ok := LookupIncomeSection(income.Section)
+		if !ok {
+			return nil, i18n.Errorf("invalid income section %q", income.Section)
+		}
+		rate, ok := section.Rates[income.SubType]
+		if !ok {
+			return nil, i18n.Errorf("invalid subType %q for income section %s", income.SubType, income.Section)
+		}
+
+		var expense money.Money
+		if income.ExpenseMethod == ExpenseMethodActual {
+			if !section.AllowsActual {
+				return nil, i18n.Errorf("income section %s does not allow actual expenses", income.Section)
+			}
+			expense = money.Min(income.ActualExpenses, income.Amount)
+		} else {
+			expense = income.Amount.Mul(rate)
+			if section.Cap != nil {
+				capKey := section.CapGroup
+				if capKey == "" {
+					capKey = section.Section
+				}
+				remaining, ok := remainingCaps[capKey]
+				if !ok {
+					remaining = *section.Cap
+				}
+				expense = money.Min(expense, remaining)
+				remainingCaps[capKey] = remaining.Sub(expense)
+			}
+		}
+
+		results = append(results, IncomeExpense{
+			Section:    income.Section,
+			SubType:    income.SubType,
+			Amount:     income.Amount,
+			Expense:    expense,
+			NetIncome:  income.Amount.Sub(expense),
+			Conversion: income.Conversion,
+		})
+	}
+
+	return results, nil
+}
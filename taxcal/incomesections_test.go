@@ -0,0 +1,84 @@
+package taxcal
+
+import (
+	"testing"
+
+	"github.com/windeesel365/assessment-tax/money"
+)
+
+func TestCalculateIncomeExpenses(t *testing.T) {
+	tests := []struct {
+		name     string
+		incomes  []Income
+		expenses []money.Money
+		wantErr  bool
+	}{
+		{
+			name:     "Salary capped at 100,000",
+			incomes:  []Income{{Section: "40(1)", Amount: money.NewFromInt(600000)}},
+			expenses: []money.Money{money.NewFromInt(100000)},
+		},
+		{
+			name: "Salary and 40(2) share one cap",
+			incomes: []Income{
+				{Section: "40(1)", Amount: money.NewFromInt(160000)},
+				{Section: "40(2)", Amount: money.NewFromInt(100000)},
+			},
+			expenses: []money.Money{money.NewFromInt(80000), money.NewFromInt(20000)},
+		},
+		{
+			name: "Rent flat rates by subType",
+			incomes: []Income{
+				{Section: "40(5)", SubType: "building", Amount: money.NewFromInt(100000)},
+				{Section: "40(5)", SubType: "land", Amount: money.NewFromInt(100000)},
+				{Section: "40(5)", SubType: "other", Amount: money.NewFromInt(100000)},
+			},
+			expenses: []money.Money{money.NewFromInt(30000), money.NewFromInt(15000), money.NewFromInt(10000)},
+		},
+		{
+			name: "Professional fees",
+			incomes: []Income{
+				{Section: "40(6)", SubType: "medical", Amount: money.NewFromInt(100000)},
+				{Section: "40(6)", Amount: money.NewFromInt(100000)},
+			},
+			expenses: []money.Money{money.NewFromInt(60000), money.NewFromInt(30000)},
+		},
+		{
+			name: "Business flat and actual expenses",
+			incomes: []Income{
+				{Section: "40(8)", Amount: money.NewFromInt(1000000)},
+				{Section: "40(8)", Amount: money.NewFromInt(500000), ExpenseMethod: ExpenseMethodActual, ActualExpenses: money.NewFromInt(420000)},
+			},
+			expenses: []money.Money{money.NewFromInt(600000), money.NewFromInt(420000)},
+		},
+		{
+			name:     "Interest has no expenses",
+			incomes:  []Income{{Section: "40(4)", Amount: money.NewFromInt(50000)}},
+			expenses: []money.Money{money.Zero},
+		},
+		{
+			name:    "Unknown section",
+			incomes: []Income{{Section: "40(9)", Amount: money.NewFromInt(1)}},
+			wantErr: true,
+		},
+		{
+			name:    "Actual expenses not allowed for salary",
+			incomes: []Income{{Section: "40(1)", Amount: money.NewFromInt(1), ExpenseMethod: ExpenseMethodActual}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CalculateIncomeExpenses(tt.incomes)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CalculateIncomeExpenses() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for i, want := range tt.expenses {
+				if !got[i].Expense.Equal(want) {
+					t.Errorf("CalculateIncomeExpenses()[%d].Expense = %v, want %v", i, got[i].Expense, want)
+				}
+			}
+		})
+	}
+}
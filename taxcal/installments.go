@@ -0,0 +1,50 @@
+package taxcal
+
+import "github.com/windeesel365/assessment-tax/money"
+
+// การผ่อนชำระภาษี ภ.ง.ด. 90/91
+const InstallmentCount = 3
+
+// InstallmentMinimumTax ภาษีที่ต้องชำระขั้นต่ำที่ผ่อนชำระได้
+var InstallmentMinimumTax = money.NewFromInt(3000)
+
+// Installment คือการผ่อนชำระหนึ่งงวด
+// response แสดง amount เป็นสตางค์ตาม money.DefaultPolicy เพื่อให้ยอดรวมของทุกงวดเท่ากับภาษีที่ต้องชำระ
+type Installment struct {
+	Installment int         `json:"installment"`
+	DueDate     Date        `json:"dueDate"`
+	Amount      money.Money `json:"amount"`
+}
+
+// InstallmentPlan คือแผนผ่อนชำระ, Eligible เป็น false เมื่อผ่อนชำระไม่ได้ และ Installments ว่าง
+type InstallmentPlan struct {
+	Eligible     bool          `json:"eligible"`
+	Installments []Installment `json:"installments"`
+}
+
+// CalculateInstallmentPlan แบ่งภาษีที่ต้องชำระเป็น 3 งวดเท่า ๆ กัน เมื่อภาษีไม่น้อยกว่า 3,000 บาท
+// และยื่นแบบภายในกำหนด, งวดแรกครบกำหนดวันเดียวกับกำหนดยื่นแบบ งวดถัดไปห่างกันงวดละ 1 เดือน
+// แต่ละงวดตัดเศษเป็นสตางค์ และเศษที่เหลือรวมในงวดแรก เพื่อให้ยอดรวมเท่ากับภาษีที่ต้องชำระพอดี
+func CalculateInstallmentPlan(result TaxResult) InstallmentPlan {
+	plan := InstallmentPlan{Installments: []Installment{}}
+	if result.TaxPayable.LessThan(InstallmentMinimumTax) {
+		return plan
+	}
+	deadline := FilingDeadline(result.TaxYear)
+	if result.LateFiling != nil && result.LateFiling.FilingDate.After(deadline.Time) {
+		return plan
+	}
+
+	plan.Eligible = true
+	amount := money.NewFromDecimal(result.TaxPayable.DivInt(InstallmentCount).Decimal().Truncate(2))
+	first := result.TaxPayable.Sub(amount.MulInt(InstallmentCount - 1))
+	for i := 0; i < InstallmentCount; i++ {
+		installment := Installment{Installment: i + 1, DueDate: addMonths(deadline, i), Amount: amount}
+		if i == 0 {
+			installment.Amount = first
+		}
+		plan.Installments = append(plan.Installments, installment)
+	}
+
+	return plan
+}
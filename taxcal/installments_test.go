@@ -0,0 +1,77 @@
+package taxcal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/windeesel365/assessment-tax/money"
+)
+
+func TestCalculateInstallmentPlan(t *testing.T) {
+	lateFiling := CalculateLateFiling(2567, money.NewFromInt(29000), NewDate(2025, time.April, 10), NewDate(2025, time.April, 10))
+	onTimeFiling := CalculateLateFiling(2567, money.NewFromInt(29000), NewDate(2025, time.March, 1), NewDate(2025, time.March, 1))
+
+	tests := []struct {
+		name        string
+		result      TaxResult
+		wantAmounts []money.Money
+	}{
+		{
+			name:        "Split with remainder in first installment",
+			result:      TaxResult{TaxYear: 2567, TaxPayable: money.NewFromInt(29000)},
+			wantAmounts: []money.Money{money.New(9666.68), money.New(9666.66), money.New(9666.66)},
+		},
+		{
+			name:        "Minimum tax",
+			result:      TaxResult{TaxYear: 2567, TaxPayable: money.NewFromInt(3000)},
+			wantAmounts: []money.Money{money.NewFromInt(1000), money.NewFromInt(1000), money.NewFromInt(1000)},
+		},
+		{
+			name:        "Filed on time",
+			result:      TaxResult{TaxYear: 2567, TaxPayable: money.New(3000.01), LateFiling: &onTimeFiling},
+			wantAmounts: []money.Money{money.New(1000.01), money.NewFromInt(1000), money.NewFromInt(1000)},
+		},
+		{
+			name:   "Below minimum",
+			result: TaxResult{TaxYear: 2567, TaxPayable: money.New(2999.99)},
+		},
+		{
+			name:   "Filed late",
+			result: TaxResult{TaxYear: 2567, TaxPayable: money.NewFromInt(29000), LateFiling: &lateFiling},
+		},
+	}
+
+	wantDueDates := []Date{NewDate(2025, time.March, 31), NewDate(2025, time.April, 30), NewDate(2025, time.May, 31)}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CalculateInstallmentPlan(tt.result)
+			if got.Eligible != (tt.wantAmounts != nil) || len(got.Installments) != len(tt.wantAmounts) {
+				t.Fatalf("CalculateInstallmentPlan() = %+v, want %d installments", got, len(tt.wantAmounts))
+			}
+			total := money.Zero
+			for i, installment := range got.Installments {
+				if !installment.Amount.Equal(tt.wantAmounts[i]) || !installment.DueDate.Equal(wantDueDates[i].Time) {
+					t.Errorf("installment %d = %v due %v, want %v due %v", i+1, installment.Amount, installment.DueDate, tt.wantAmounts[i], wantDueDates[i])
+				}
+				total = total.Add(installment.Amount)
+			}
+			if got.Eligible && !total.Equal(tt.result.TaxPayable) {
+				t.Errorf("installments total = %v, want %v", total, tt.result.TaxPayable)
+			}
+		})
+	}
+}
+
+func TestInstallmentPlanJSON(t *testing.T) {
+	plan := InstallmentPlan{Eligible: true, Installments: []Installment{
+		{Installment: 1, DueDate: NewDate(2025, time.March, 31), Amount: money.New(9666.68)},
+	}}
+	got, err := money.DefaultPolicy().Marshal(map[string]interface{}{"installmentPlan": plan})
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if want := `{"installmentPlan":{"eligible":true,"installments":[{"installment":1,"dueDate":"2025-03-31","amount":9666.68}]}}`; string(got) != want {
+		t.Errorf("Marshal() = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,89 @@
+package taxcal
+
+import (
+	"time"
+
+	"github.com/windeesel365/assessment-tax/money"
+)
+
+// เงินเพิ่มและค่าปรับของการยื่นแบบ ภ.ง.ด. 90/91 หรือชำระภาษีเกินกำหนด
+var (
+	// SurchargeMonthlyRate เงินเพิ่ม 1.5% ต่อเดือนหรือเศษของเดือน (มาตรา 27) ไม่เกินภาษีที่ต้องชำระ
+	SurchargeMonthlyRate = money.Rate(0.015)
+	// LateFilingFine ค่าปรับยื่นแบบเกินกำหนดไม่เกิน LateFilingFineDays วัน
+	LateFilingFine = money.NewFromInt(200)
+	// LateFilingFineAfterDays ค่าปรับยื่นแบบเกินกำหนดเกิน LateFilingFineDays วัน (ไม่เกิน 2,000 บาทตามมาตรา 35)
+	LateFilingFineAfterDays = money.NewFromInt(400)
+)
+
+// LateFilingFineDays จำนวนวันหลังกำหนดยื่นแบบที่ใช้ค่าปรับอัตราต่ำ
+const LateFilingFineDays = 7
+
+// LateFiling คือเงินเพิ่มและค่าปรับที่ต้องชำระเพิ่มจากภาษี
+type LateFiling struct {
+	Deadline    Date `json:"deadline"`
+	FilingDate  Date `json:"filingDate"`
+	PaymentDate Date `json:"paymentDate"`
+	// MonthsLate คือจำนวนเดือนหรือเศษของเดือน นับจากวันครบกำหนดถึงวันที่ชำระ
+	MonthsLate int         `json:"monthsLate"`
+	Surcharge  money.Money `json:"surcharge"`
+	Penalty    money.Money `json:"penalty"`
+	// TotalPayable คือภาษีที่ต้องชำระ + เงินเพิ่ม + ค่าปรับ, Calculate หักค่าปรับกับเงินที่ได้คืนก่อน
+	TotalPayable money.Money `json:"totalPayable"`
+}
+
+// FilingDeadline วันครบกำหนดยื่นแบบของปีภาษี (พ.ศ.) คือ 31 มีนาคมของปีถัดไป
+func FilingDeadline(taxYear int) Date {
+	return NewDate(GregorianYear(taxYear)+1, time.March, 31)
+}
+
+// CalculateLateFiling คำนวนเงินเพิ่มจากวันที่ชำระ และค่าปรับจากวันที่ยื่นแบบ
+// paymentDate ต้องไม่ก่อน filingDate (ผ่าน validityguard มาแล้ว)
+func CalculateLateFiling(taxYear int, taxPayable money.Money, filingDate, paymentDate Date) LateFiling {
+	deadline := FilingDeadline(taxYear)
+	late := LateFiling{
+		Deadline:    deadline,
+		FilingDate:  filingDate,
+		PaymentDate: paymentDate,
+		MonthsLate:  monthsLate(deadline, paymentDate),
+		Surcharge:   money.Zero,
+		Penalty:     money.Zero,
+	}
+
+	// เงินเพิ่มไม่เกินภาษีที่ต้องชำระ
+	if taxPayable.IsPositive() {
+		surcharge := taxPayable.Mul(SurchargeMonthlyRate).MulInt(int64(late.MonthsLate)).Round(2)
+		late.Surcharge = money.Min(surcharge, taxPayable)
+	}
+
+	if filingDate.After(deadline.Time) {
+		late.Penalty = LateFilingFine
+		if filingDate.After(deadline.AddDate(0, 0, LateFilingFineDays)) {
+			late.Penalty = LateFilingFineAfterDays
+		}
+	}
+
+	late.TotalPayable = taxPayable.Add(late.Surcharge).Add(late.Penalty)
+
+	return late
+}
+
+// monthsLate นับเดือนหรือเศษของเดือนหลัง deadline จนถึง date เช่น 31 มี.ค. -> 30 เม.ย. คือ 1 เดือน
+func monthsLate(deadline, date Date) int {
+	months := 0
+	for date.After(addMonths(deadline, months).Time) {
+		months++
+	}
+	return months
+}
+
+// addMonths เลื่อนไป n เดือน โดยวันที่ไม่เกินวันสุดท้ายของเดือน (31 มี.ค. + 1 เดือน = 30 เม.ย.)
+func addMonths(d Date, n int) Date {
+	first := time.Date(d.Year(), d.Month()+time.Month(n), 1, 0, 0, 0, 0, time.UTC)
+	lastDay := first.AddDate(0, 1, -1).Day()
+	day := d.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return NewDate(first.Year(), first.Month(), day)
+}
@@ -0,0 +1,49 @@
+package taxcal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/windeesel365/assessment-tax/money"
+)
+
+func TestFilingDeadline(t *testing.T) {
+	if got, want := FilingDeadline(2567), NewDate(2025, time.March, 31); !got.Equal(want.Time) {
+		t.Errorf("FilingDeadline(2567) = %v, want %v", got, want)
+	}
+}
+
+func TestCalculateLateFiling(t *testing.T) {
+	tests := []struct {
+		name          string
+		taxPayable    money.Money
+		filingDate    Date
+		paymentDate   Date
+		wantMonths    int
+		wantSurcharge money.Money
+		wantPenalty   money.Money
+	}{
+		{"On time", money.NewFromInt(29000), NewDate(2025, time.March, 31), NewDate(2025, time.March, 31), 0, money.Zero, money.Zero},
+		{"One day late", money.NewFromInt(29000), NewDate(2025, time.April, 1), NewDate(2025, time.April, 1), 1, money.NewFromInt(435), money.NewFromInt(200)},
+		{"End of first month", money.NewFromInt(29000), NewDate(2025, time.April, 30), NewDate(2025, time.April, 30), 1, money.NewFromInt(435), money.NewFromInt(400)},
+		{"Fraction of second month", money.NewFromInt(29000), NewDate(2025, time.May, 1), NewDate(2025, time.May, 1), 2, money.NewFromInt(870), money.NewFromInt(400)},
+		{"Filed on time paid late", money.NewFromInt(10000), NewDate(2025, time.March, 1), NewDate(2025, time.June, 15), 3, money.NewFromInt(450), money.Zero},
+		{"Surcharge capped at tax", money.NewFromInt(1000), NewDate(2031, time.March, 31), NewDate(2031, time.March, 31), 72, money.NewFromInt(1000), money.NewFromInt(400)},
+		{"Refund has no surcharge", money.Zero, NewDate(2025, time.May, 1), NewDate(2025, time.May, 1), 2, money.Zero, money.NewFromInt(400)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CalculateLateFiling(2567, tt.taxPayable, tt.filingDate, tt.paymentDate)
+			if got.MonthsLate != tt.wantMonths {
+				t.Errorf("MonthsLate = %d, want %d", got.MonthsLate, tt.wantMonths)
+			}
+			if !got.Surcharge.Equal(tt.wantSurcharge) || !got.Penalty.Equal(tt.wantPenalty) {
+				t.Errorf("Surcharge, Penalty = %v, %v; want %v, %v", got.Surcharge, got.Penalty, tt.wantSurcharge, tt.wantPenalty)
+			}
+			if want := tt.taxPayable.Add(tt.wantSurcharge).Add(tt.wantPenalty); !got.TotalPayable.Equal(want) {
+				t.Errorf("TotalPayable = %v, want %v", got.TotalPayable, want)
+			}
+		})
+	}
+}
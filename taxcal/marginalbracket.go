@@ -0,0 +1,15 @@
+package taxcal
+
+import "github.com/windeesel365/assessment-tax/money"
+
+// MarginalBracket หาขั้นบันใดภาษีที่เงินได้สุทธิบาทสุดท้ายตกอยู่
+func MarginalBracket(taxableIncome money.Money, brackets []TaxBracket) TaxBracket {
+	marginal := brackets[0]
+	for _, level := range brackets {
+		// min ต้อง -1 ด้วย; เพราะเรา define taxLevels ขอบล่างลงท้าย 1
+		if taxableIncome.GreaterThan(level.Min.Sub(money.NewFromInt(1))) {
+			marginal = level
+		}
+	}
+	return marginal
+}
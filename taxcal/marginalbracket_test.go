@@ -0,0 +1,29 @@
+package taxcal
+
+import (
+	"testing"
+
+	"github.com/windeesel365/assessment-tax/money"
+)
+
+func TestMarginalBracket(t *testing.T) {
+	tests := []struct {
+		name          string
+		taxableIncome money.Money
+		wantMin       money.Money
+	}{
+		{"Zero income", money.Zero, money.Zero},
+		{"Top of first bracket", money.NewFromInt(150000), money.Zero},
+		{"Start of second bracket", money.New(150000.01), money.NewFromInt(150001)},
+		{"Middle bracket", money.NewFromInt(750000), money.NewFromInt(500001)},
+		{"No upper limit", money.NewFromInt(5000000), money.NewFromInt(2000001)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MarginalBracket(tt.taxableIncome, taxBrackets2567); !got.Min.Equal(tt.wantMin) {
+				t.Errorf("MarginalBracket(%v).Min = %v, want %v", tt.taxableIncome, got.Min, tt.wantMin)
+			}
+		})
+	}
+}
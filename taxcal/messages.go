@@ -0,0 +1,67 @@
+package taxcal
+
+import "github.com/windeesel365/assessment-tax/i18n"
+
+// คำแปลของข้อความใน package taxcal: error และ Rule ของ Trace ต้นฉบับเป็นภาษาอังกฤษ
+// ส่วน label ขั้นบันใดภาษีต้นฉบับเป็นภาษาไทย
+func init() {
+	i18n.Register(i18n.English, map[string]string{
+		"%s ขึ้นไป": "%s and over",
+	})
+
+	i18n.Register(i18n.Thai, map[string]string{
+		// error
+		"invalid allowance type. Please ensure the filled type is one of: %s":                           "allowanceType ไม่ถูกต้อง กรุณาระบุชนิดใดชนิดหนึ่งต่อไปนี้: %s",
+		"allowanceType %s is redundant, please check and fill again":                                    "allowanceType %s ซ้ำ กรุณาตรวจสอบแล้วกรอกใหม่",
+		"date must be a string in YYYY-MM-DD format":                                                    "date ต้องเป็น string ในรูปแบบ YYYY-MM-DD",
+		"date %q must be in YYYY-MM-DD format":                                                          "date %q ต้องอยู่ในรูปแบบ YYYY-MM-DD",
+		"dividendMethod must be %s or %s":                                                               "dividendMethod ต้องเป็น %s หรือ %s",
+		"invalid income section %q":                                                                     "ประเภทเงินได้ %q ไม่ถูกต้อง",
+		"invalid subType %q for income section %s":                                                      "subType %q ไม่ถูกต้องสำหรับเงินได้ %s",
+		"income section %s does not allow actual expenses":                                              "เงินได้ %s หักค่าใช้จ่ายตามจริงไม่ได้",
+		"target must be %s or %s":                                                                       "target ต้องเป็น %s หรือ %s",
+		"no income found for %s target %s":                                                              "ไม่พบเงินได้ที่ได้ %s ตามเป้าหมาย %s",
+		"taxYear %d is not supported, supported tax years are %v":                                       "ไม่รองรับ taxYear %d, ปีภาษีที่รองรับคือ %v",
+		"The %s must not be negative. Please enter an amount of 0 THB or more and try again.":           "%s ต้องไม่ติดลบ กรุณาใส่จำนวนตั้งแต่ 0 บาทขึ้นไปแล้วลองใหม่",
+		"The personal exemption must be more than 10,000 THB.  Please update the amount and try again.": "ค่าลดหย่อนส่วนตัวต้องมากกว่า 10,000 บาท กรุณาแก้ไขจำนวนแล้วลองใหม่",
+		"The donation must be more than 0 THB. Please enter a positive amount and try again.":           "เงินบริจาคต้องมากกว่า 0 บาท กรุณาใส่จำนวนที่เป็นบวกแล้วลองใหม่",
+		"The kReceipts must be more than 0 THB. Please enter a positive amount and try again.":          "ช้อปลดหย่อนต้องมากกว่า 0 บาท กรุณาใส่จำนวนที่เป็นบวกแล้วลองใหม่",
+
+		// ชื่อค่าลดหย่อนใน MinMessage
+		"education donation":                "เงินบริจาคเพื่อการศึกษา",
+		"sports donation":                   "เงินบริจาคเพื่อการกีฬา",
+		"public hospital donation":          "เงินบริจาคให้โรงพยาบาลรัฐ",
+		"political party donation":          "เงินบริจาคพรรคการเมือง",
+		"life insurance premium":            "เบี้ยประกันชีวิต",
+		"health insurance premium":          "เบี้ยประกันสุขภาพ",
+		"parents' health insurance premium": "เบี้ยประกันสุขภาพบิดามารดา",
+		"SSF purchase":                      "ค่าซื้อ SSF",
+		"RMF purchase":                      "ค่าซื้อ RMF",
+		"provident fund contribution":       "เงินสะสมกองทุนสำรองเลี้ยงชีพ",
+		"social security contribution":      "เงินสมทบประกันสังคม",
+		"home loan interest":                "ดอกเบี้ยเงินกู้ยืมเพื่อที่อยู่อาศัย",
+
+		// Rule ของ Trace
+		"totalIncome + %d income(s)": "totalIncome + เงินได้ %d รายการ",
+		"less expenses %s":           "หักค่าใช้จ่าย %s",
+		"less deductions %s":         "หักค่าลดหย่อน %s",
+		"higher of progressive tax and %s of 40(2)-40(8) income = %s": "ใช้ค่าที่สูงกว่าระหว่างภาษีขั้นบันใด และ %s ของเงินได้ 40(2)-40(8) = %s",
+		"add severance tax %s":                                            "บวกภาษีเงินได้ก้อนเดียว %s",
+		"less wht %s, negative is taxRefund":                              "หักภาษี ณ ที่จ่าย %s, ติดลบคือ taxRefund",
+		"surcharge %s x %d month(s) = %s, penalty %s":                     "เงินเพิ่ม %s x %d เดือน = %s, ค่าปรับ %s",
+		"final %s withheld = %s, include with tax credit %s = %s, use %s": "ภาษีสุดท้าย %s ที่ถูกหัก = %s, รวมคำนวนพร้อมเครดิตภาษี %s = %s, ใช้ %s",
+		"expense %s":                           "ค่าใช้จ่าย %s",
+		"expense %s capped at %s":              "ค่าใช้จ่าย %s ไม่เกิน %s",
+		"expense %s capped at %s shared by %s": "ค่าใช้จ่าย %s ไม่เกิน %s รวมกันของ %s",
+		"actual expenses":                      "ค่าใช้จ่ายตามจริง",
+		"default":                              "ค่าเริ่มต้น",
+		"cap %s":                               "เพดาน %s",
+		"claims up to %s get %s":               "ยื่นไม่เกิน %s ได้ %s",
+		"group %s cap %s of net income after other allowances = %s": "เพดานรวม %s %s ของเงินได้หลังหักค่าลดหย่อนอื่น = %s",
+		"group %s cap %s": "เพดานรวม %s %s",
+		"no cap":          "ไม่มีเพดาน",
+		"%d person(s)":    "%d คน",
+		"less %s x %d year(s), not exceeding amount": "หัก %s x %d ปี ไม่เกินจำนวนเงินได้",
+		"less %s": "หัก %s",
+	})
+}
@@ -0,0 +1,133 @@
+package taxcal
+
+import "github.com/windeesel365/assessment-tax/money"
+
+// MonthsPerYear คือจำนวนงวดเงินเดือนในหนึ่งปีภาษี
+const MonthsPerYear = 12
+
+// SalaryPeriod คือเงินเดือนต่อเดือนตั้งแต่เดือน FromMonth (1-12) จนกว่าจะมีการเปลี่ยนเงินเดือนครั้งถัดไป
+type SalaryPeriod struct {
+	FromMonth int         `json:"fromMonth"`
+	Amount    money.Money `json:"amount"`
+}
+
+// Bonus คือโบนัสหรือเงินได้ไม่ประจำที่จ่ายในเดือน Month
+type Bonus struct {
+	Month  int         `json:"month"`
+	Amount money.Money `json:"amount"`
+}
+
+// PayrollInput คือข้อมูลเงินเดือนทั้งปี และค่าลดหย่อนที่ลูกจ้างแจ้งนายจ้าง (ล.ย.01)
+type PayrollInput struct {
+	TaxYear    int
+	Salaries   []SalaryPeriod
+	Bonuses    []Bonus
+	Allowances []AllowanceClaim
+	Dependants *Dependants
+}
+
+// MonthlyWithholding คือภาษีหัก ณ ที่จ่ายของหนึ่งเดือน
+type MonthlyWithholding struct {
+	Month  int         `json:"month"`
+	Salary money.Money `json:"salary"`
+	Bonus  money.Money `json:"bonus"`
+	// ProjectedIncome คือเงินได้ทั้งปีที่ประมาณการ ณ เดือนนี้ (ไม่รวมโบนัสของเดือนนี้)
+	ProjectedIncome money.Money `json:"projectedIncome"`
+	Withholding     money.Money `json:"withholding"`
+}
+
+// PayrollResult คือตารางภาษีหัก ณ ที่จ่ายทั้ง 12 เดือน
+type PayrollResult struct {
+	TaxYear          int                  `json:"taxYear"`
+	AnnualIncome     money.Money          `json:"annualIncome"`
+	AnnualTax        money.Money          `json:"annualTax"`
+	TotalWithholding money.Money          `json:"totalWithholding"`
+	Months           []MonthlyWithholding `json:"months"`
+}
+
+// CalculatePayrollWithholding คำนวนภาษีหัก ณ ที่จ่ายรายเดือนตามวิธีของกรมสรรพากร แบบสะสม:
+// แต่ละเดือนประมาณการเงินได้ทั้งปี = เงินได้ที่จ่ายแล้ว + เงินเดือนปัจจุบัน x เดือนที่เหลือ
+// แล้วเฉลี่ยภาษีทั้งปีที่ยังไม่ได้หักให้เดือนที่เหลือ, เมื่อเงินเดือนเปลี่ยนก็คำนวนใหม่อัตโนมัติ
+// โบนัสหักภาษีส่วนที่เพิ่มขึ้นทั้งหมดในเดือนที่จ่าย, เดือนสุดท้ายหักส่วนที่เหลือให้ครบภาษีทั้งปี
+func CalculatePayrollWithholding(in PayrollInput) (PayrollResult, error) {
+	result := PayrollResult{TaxYear: in.TaxYear}
+	if result.TaxYear == 0 {
+		result.TaxYear = DefaultTaxYear
+	}
+
+	// ภาษีทั้งปีของเงินได้ 40(1) จำนวน income ด้วยค่าลดหย่อนที่แจ้งไว้
+	taxFor := func(income money.Money) (money.Money, error) {
+		r, err := Calculate(TaxInput{
+			TaxYear:    in.TaxYear,
+			Incomes:    []Income{{Section: salarySection, Amount: income}},
+			Allowances: in.Allowances,
+			Dependants: in.Dependants,
+		})
+		return r.Tax, err
+	}
+
+	paid, withheld := money.Zero, money.Zero
+	for month := 1; month <= MonthsPerYear; month++ {
+		salary := salaryForMonth(in.Salaries, month)
+		bonus := bonusForMonth(in.Bonuses, month)
+		remaining := int64(MonthsPerYear - month + 1)
+
+		projected := paid.Add(salary.MulInt(remaining))
+		regularTax, err := taxFor(projected)
+		if err != nil {
+			return PayrollResult{}, err
+		}
+		withholding := money.Max(regularTax.Sub(withheld).DivInt(remaining).Round(2), money.Zero)
+
+		// ภาษีส่วนเพิ่มจากโบนัส หักทั้งหมดในเดือนที่จ่าย
+		if bonus.IsPositive() {
+			bonusTax, err := taxFor(projected.Add(bonus))
+			if err != nil {
+				return PayrollResult{}, err
+			}
+			withholding = withholding.Add(bonusTax.Sub(regularTax))
+		}
+
+		result.Months = append(result.Months, MonthlyWithholding{
+			Month:           month,
+			Salary:          salary,
+			Bonus:           bonus,
+			ProjectedIncome: projected,
+			Withholding:     withholding,
+		})
+		paid = paid.Add(salary).Add(bonus)
+		withheld = withheld.Add(withholding)
+	}
+
+	annualTax, err := taxFor(paid)
+	if err != nil {
+		return PayrollResult{}, err
+	}
+	result.AnnualIncome = paid
+	result.AnnualTax = annualTax
+	result.TotalWithholding = withheld
+
+	return result, nil
+}
+
+// salaryForMonth คืนเงินเดือนของ SalaryPeriod ล่าสุดที่เริ่มไม่เกินเดือน month (0 ถ้ายังไม่เริ่มงาน)
+func salaryForMonth(salaries []SalaryPeriod, month int) money.Money {
+	salary := money.Zero
+	for _, period := range salaries {
+		if period.FromMonth <= month {
+			salary = period.Amount
+		}
+	}
+	return salary
+}
+
+// bonusForMonth รวมโบนัสทั้งหมดที่จ่ายในเดือน month
+func bonusForMonth(bonuses []Bonus, month int) money.Money {
+	total := money.Zero
+	for _, bonus := range bonuses {
+		if bonus.Month == month {
+			total = total.Add(bonus.Amount)
+		}
+	}
+	return total
+}
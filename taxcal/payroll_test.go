@@ -0,0 +1,93 @@
+package taxcal
+
+import (
+	"testing"
+
+	"github.com/windeesel365/assessment-tax/money"
+)
+
+func TestCalculatePayrollWithholding(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         PayrollInput
+		wantAnnualTax money.Money
+		// เดือน -> ภาษีหัก ณ ที่จ่ายที่คาดไว้
+		wantMonths map[int]money.Money
+	}{
+		{
+			name: "Fixed salary",
+			// 600,000 - 100,000 (ค่าใช้จ่าย) - 60,000 = 440,000 -> 29,000
+			input:         PayrollInput{Salaries: []SalaryPeriod{{FromMonth: 1, Amount: money.NewFromInt(50000)}}},
+			wantAnnualTax: money.NewFromInt(29000),
+			wantMonths: map[int]money.Money{
+				1:  money.New(2416.67),
+				11: money.New(2416.67),
+				12: money.New(2416.66),
+			},
+		},
+		{
+			name: "Salary raise in July",
+			input: PayrollInput{Salaries: []SalaryPeriod{
+				{FromMonth: 1, Amount: money.NewFromInt(50000)},
+				{FromMonth: 7, Amount: money.NewFromInt(60000)},
+			}},
+			// 660,000 -> เงินได้สุทธิ 500,000 -> 35,000
+			wantAnnualTax: money.NewFromInt(35000),
+			wantMonths: map[int]money.Money{
+				1:  money.New(2416.67),
+				7:  money.New(3416.67),
+				12: money.New(3416.66),
+			},
+		},
+		{
+			name: "Bonus in March",
+			input: PayrollInput{
+				Salaries: []SalaryPeriod{{FromMonth: 1, Amount: money.NewFromInt(50000)}},
+				Bonuses:  []Bonus{{Month: 3, Amount: money.NewFromInt(100000)}},
+			},
+			// 700,000 -> เงินได้สุทธิ 540,000 -> 41,000, โบนัสเพิ่มภาษี 12,000
+			wantAnnualTax: money.NewFromInt(41000),
+			wantMonths: map[int]money.Money{
+				2: money.New(2416.67),
+				3: money.New(14416.67),
+			},
+		},
+		{
+			name: "Joins in October with allowances",
+			input: PayrollInput{
+				Salaries:   []SalaryPeriod{{FromMonth: 10, Amount: money.NewFromInt(100000)}},
+				Allowances: []AllowanceClaim{{AllowanceType: "social-security", Amount: money.NewFromInt(2250)}},
+			},
+			// 300,000 - 100,000 - 60,000 - 2,250 = 137,750 -> 0
+			wantAnnualTax: money.Zero,
+			wantMonths: map[int]money.Money{
+				1:  money.Zero,
+				10: money.Zero,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CalculatePayrollWithholding(tt.input)
+			if err != nil {
+				t.Fatalf("CalculatePayrollWithholding() error = %v", err)
+			}
+			if len(got.Months) != MonthsPerYear {
+				t.Fatalf("CalculatePayrollWithholding() returned %d months, want %d", len(got.Months), MonthsPerYear)
+			}
+			if !got.AnnualTax.Equal(tt.wantAnnualTax) {
+				t.Errorf("AnnualTax = %v, want %v", got.AnnualTax, tt.wantAnnualTax)
+			}
+			// เดือนสุดท้ายหักส่วนที่เหลือ ยอดรวมต้องเท่าภาษีทั้งปีพอดี
+			if !got.TotalWithholding.Equal(got.AnnualTax) {
+				t.Errorf("TotalWithholding = %v, want %v", got.TotalWithholding, got.AnnualTax)
+			}
+			for month, want := range tt.wantMonths {
+				if w := got.Months[month-1].Withholding; !w.Equal(want) {
+					t.Errorf("month %d withholding = %v, want %v", month, w, want)
+				}
+			}
+		})
+	}
+}
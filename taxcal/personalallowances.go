@@ -0,0 +1,65 @@
+package taxcal
+
+import (
+	"github.com/windeesel365/assessment-tax/i18n"
+	"github.com/windeesel365/assessment-tax/money"
+)
+
+// ชื่อเพดานรวมของค่าลดหย่อน
+const (
+	// เบี้ยประกันชีวิตรวมกับเบี้ยประกันสุขภาพตนเองไม่เกิน 100,000 บาท
+	LifeHealthInsuranceGroup = "life-health-insurance"
+	// SSF, RMF และกองทุนสำรองเลี้ยงชีพรวมกันไม่เกิน 500,000 บาท
+	RetirementSavingsGroup = "retirement-savings"
+)
+
+// incomeRate เพดานเป็นสัดส่วนของเงินได้ เช่น SSF ไม่เกิน 30% ของเงินได้
+func incomeRate(rate float64) func(income money.Money) money.Money {
+	return func(income money.Money) money.Money {
+		return income.Mul(money.Rate(rate))
+	}
+}
+
+// nonNegativeAllowance กฎพื้นฐานของค่าลดหย่อนที่ amount ต้องไม่ติดลบ และมีเพดานคงที่
+func nonNegativeAllowance(name, label string, cap int64) AllowanceRule {
+	return AllowanceRule{
+		Name:       name,
+		Min:        money.Zero,
+		MinMessage: i18n.Msg("The %s must not be negative. Please enter an amount of 0 THB or more and try again.", i18n.Text(label)),
+		Cap:        FixedCap(money.NewFromInt(cap)),
+	}
+}
+
+// ค่าลดหย่อนตามแบบ ภ.ง.ด. 90/91
+func init() {
+	RegisterAllowanceGroup(AllowanceGroup{Name: LifeHealthInsuranceGroup, Cap: FixedCap(money.NewFromInt(100000))})
+	RegisterAllowanceGroup(AllowanceGroup{Name: RetirementSavingsGroup, Cap: FixedCap(money.NewFromInt(500000))})
+
+	lifeInsurance := nonNegativeAllowance("life-insurance", "life insurance premium", 100000)
+	lifeInsurance.Group = LifeHealthInsuranceGroup
+	RegisterAllowance(lifeInsurance)
+
+	healthInsurance := nonNegativeAllowance("health-insurance", "health insurance premium", 25000)
+	healthInsurance.Group = LifeHealthInsuranceGroup
+	RegisterAllowance(healthInsurance)
+
+	RegisterAllowance(nonNegativeAllowance("parents-health-insurance", "parents' health insurance premium", 15000))
+
+	ssf := nonNegativeAllowance("ssf", "SSF purchase", 200000)
+	ssf.IncomeCap = incomeRate(0.3)
+	ssf.Group = RetirementSavingsGroup
+	RegisterAllowance(ssf)
+
+	rmf := nonNegativeAllowance("rmf", "RMF purchase", 500000)
+	rmf.IncomeCap = incomeRate(0.3)
+	rmf.Group = RetirementSavingsGroup
+	RegisterAllowance(rmf)
+
+	providentFund := nonNegativeAllowance("provident-fund", "provident fund contribution", 500000)
+	providentFund.IncomeCap = incomeRate(0.15)
+	providentFund.Group = RetirementSavingsGroup
+	RegisterAllowance(providentFund)
+
+	RegisterAllowance(nonNegativeAllowance("social-security", "social security contribution", 9000))
+	RegisterAllowance(nonNegativeAllowance("home-loan-interest", "home loan interest", 100000))
+}
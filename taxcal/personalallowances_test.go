@@ -0,0 +1,111 @@
+package taxcal
+
+import (
+	"testing"
+
+	"github.com/windeesel365/assessment-tax/money"
+)
+
+func TestPersonalAllowanceLimits(t *testing.T) {
+	tests := []struct {
+		name   string
+		income money.Money
+		claims []AllowanceClaim
+		want   map[string]money.Money
+	}{
+		{
+			name:   "Individual caps",
+			income: money.NewFromInt(1000000),
+			claims: []AllowanceClaim{
+				{AllowanceType: "parents-health-insurance", Amount: money.NewFromInt(20000)},
+				{AllowanceType: "social-security", Amount: money.NewFromInt(10000)},
+				{AllowanceType: "home-loan-interest", Amount: money.NewFromInt(120000)},
+			},
+			want: map[string]money.Money{
+				"parents-health-insurance": money.NewFromInt(15000),
+				"social-security":          money.NewFromInt(9000),
+				"home-loan-interest":       money.NewFromInt(100000),
+			},
+		},
+		{
+			name:   "Life and health insurance combined cap",
+			income: money.NewFromInt(1000000),
+			claims: []AllowanceClaim{
+				{AllowanceType: "life-insurance", Amount: money.NewFromInt(90000)},
+				{AllowanceType: "health-insurance", Amount: money.NewFromInt(30000)},
+			},
+			want: map[string]money.Money{
+				"life-insurance":   money.NewFromInt(90000),
+				"health-insurance": money.NewFromInt(10000),
+			},
+		},
+		{
+			name:   "Income based caps",
+			income: money.NewFromInt(400000),
+			claims: []AllowanceClaim{
+				{AllowanceType: "ssf", Amount: money.NewFromInt(150000)},
+				{AllowanceType: "provident-fund", Amount: money.NewFromInt(80000)},
+			},
+			want: map[string]money.Money{
+				"ssf":            money.NewFromInt(120000),
+				"provident-fund": money.NewFromInt(60000),
+			},
+		},
+		{
+			name:   "Retirement savings combined cap",
+			income: money.NewFromInt(3000000),
+			claims: []AllowanceClaim{
+				{AllowanceType: "ssf", Amount: money.NewFromInt(200000)},
+				{AllowanceType: "rmf", Amount: money.NewFromInt(500000)},
+				{AllowanceType: "provident-fund", Amount: money.NewFromInt(100000)},
+			},
+			want: map[string]money.Money{
+				"ssf":            money.NewFromInt(200000),
+				"rmf":            money.NewFromInt(300000),
+				"provident-fund": money.Zero,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			applied, err := ApplyAllowances(tt.claims, tt.income)
+			if err != nil {
+				t.Fatalf("ApplyAllowances() error = %v", err)
+			}
+			for _, allowance := range applied {
+				want, ok := tt.want[allowance.AllowanceType]
+				if ok && !allowance.Amount.Equal(want) {
+					t.Errorf("ApplyAllowances() %s = %v, want %v", allowance.AllowanceType, allowance.Amount, want)
+				}
+			}
+		})
+	}
+}
+
+func TestPersonalAllowanceMinimum(t *testing.T) {
+	tests := []struct {
+		name    string
+		amount  money.Money
+		wantErr string
+	}{
+		{"Zero amount", money.Zero, ""},
+		{"Negative amount", money.NewFromInt(-1), "The RMF purchase must not be negative. Please enter an amount of 0 THB or more and try again."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := []AllowanceClaim{{AllowanceType: "rmf", Amount: tt.amount}}
+			_, err := ApplyAllowances(claims, money.NewFromInt(500000))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ApplyAllowances() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("ApplyAllowances() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package taxcal
+
+import (
+	"github.com/windeesel365/assessment-tax/i18n"
+	"github.com/windeesel365/assessment-tax/money"
+)
+
+// เป้าหมายของการคำนวนย้อนกลับ
+const (
+	ReverseTargetNet = "net" // เงินได้หลังหักภาษีที่ต้องการ
+	ReverseTargetTax = "tax" // ภาษีที่ต้องการให้ถึง
+)
+
+// ความละเอียดของคำตอบคือ 1 สตางค์
+var reversePrecision = money.New(0.01)
+
+// maxReverseDoublings จำกัดการขยายขอบบนของการค้นหา
+const maxReverseDoublings = 64
+
+// CalculateGrossIncomeFor หาเงินได้ทั้งหมดที่น้อยที่สุด ที่ทำให้ target ถึง amount
+// โดยใช้ Calculate จริง (ค่าใช้จ่าย ค่าลดหย่อน ขั้นบันใดภาษี และภาษีวิธีที่ 2) กับเงินได้ที่ลองทีละค่า
+// section ว่างคือเงินได้แบบ totalIncome เดิม, ไม่ว่างคือเงินได้ section นั้นแบบหักค่าใช้จ่ายเหมา
+func CalculateGrossIncomeFor(target string, amount money.Money, template TaxInput, section string) (TaxResult, error) {
+	var measure func(TaxResult) money.Money
+	switch target {
+	case ReverseTargetNet:
+		measure = func(r TaxResult) money.Money { return r.GrossIncome.Sub(r.Tax) }
+	case ReverseTargetTax:
+		measure = func(r TaxResult) money.Money { return r.Tax }
+	default:
+		return TaxResult{}, i18n.Errorf("target must be %s or %s", ReverseTargetNet, ReverseTargetTax)
+	}
+
+	calculateAt := func(gross money.Money) (TaxResult, error) {
+		in := template
+		if section == "" {
+			in.TotalIncome, in.Incomes = gross, nil
+		} else {
+			in.TotalIncome, in.Incomes = money.Zero, []Income{{Section: section, Amount: gross}}
+		}
+		return Calculate(in)
+	}
+
+	// ขยายขอบบนจนกว่าจะถึงเป้าหมาย, ฟังก์ชันของทั้ง 2 เป้าหมายไม่ลดลงเมื่อเงินได้เพิ่ม
+	low := money.Zero
+	high := money.Max(amount.MulInt(2), money.NewFromInt(1000000))
+	best, err := calculateAt(low)
+	if err != nil {
+		return TaxResult{}, err
+	}
+	if measure(best).GreaterThanOrEqual(amount) {
+		return best, nil
+	}
+	for i := 0; ; i++ {
+		best, err = calculateAt(high)
+		if err != nil {
+			return TaxResult{}, err
+		}
+		if measure(best).GreaterThanOrEqual(amount) {
+			break
+		}
+		if i == maxReverseDoublings {
+			return TaxResult{}, i18n.Errorf("no income found for %s target %s", target, amount)
+		}
+		low, high = high, high.MulInt(2)
+	}
+
+	// bisection ทีละครึ่งจนช่วงแคบกว่า 1 สตางค์
+	for high.Sub(low).GreaterThan(reversePrecision) {
+		mid := low.Add(high).DivInt(2).Round(2)
+		if mid.Equal(low) || mid.Equal(high) {
+			break
+		}
+		result, err := calculateAt(mid)
+		if err != nil {
+			return TaxResult{}, err
+		}
+		if measure(result).GreaterThanOrEqual(amount) {
+			high, best = mid, result
+		} else {
+			low = mid
+		}
+	}
+
+	return best, nil
+}
@@ -0,0 +1,69 @@
+package taxcal
+
+import (
+	"testing"
+
+	"github.com/windeesel365/assessment-tax/money"
+)
+
+func TestCalculateGrossIncomeFor(t *testing.T) {
+	tests := []struct {
+		name      string
+		target    string
+		amount    money.Money
+		section   string
+		wantGross money.Money
+		wantTax   money.Money
+	}{
+		{
+			// 500,000 - 60,000 = 440,000 -> 29,000 tax
+			name:      "Target tax with total income",
+			target:    ReverseTargetTax,
+			amount:    money.NewFromInt(29000),
+			wantGross: money.NewFromInt(500000),
+			wantTax:   money.NewFromInt(29000),
+		},
+		{
+			name:      "Target tax zero",
+			target:    ReverseTargetTax,
+			amount:    money.Zero,
+			wantGross: money.Zero,
+			wantTax:   money.Zero,
+		},
+		{
+			// 500,000 - 29,000 = 471,000 net
+			name:      "Target net with total income",
+			target:    ReverseTargetNet,
+			amount:    money.NewFromInt(471000),
+			wantGross: money.NewFromInt(500000),
+			wantTax:   money.NewFromInt(29000),
+		},
+		{
+			// 600,000 salary - 100,000 expenses - 60,000 = 440,000 -> 29,000 tax
+			name:      "Target tax with salary",
+			target:    ReverseTargetTax,
+			amount:    money.NewFromInt(29000),
+			section:   "40(1)",
+			wantGross: money.NewFromInt(600000),
+			wantTax:   money.NewFromInt(29000),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := CalculateGrossIncomeFor(tt.target, tt.amount, TaxInput{}, tt.section)
+			if err != nil {
+				t.Fatalf("CalculateGrossIncomeFor() error = %v", err)
+			}
+			if !result.GrossIncome.Equal(tt.wantGross) || !result.Tax.Equal(tt.wantTax) {
+				t.Errorf("CalculateGrossIncomeFor() = %v, %v; want %v, %v", result.GrossIncome, result.Tax, tt.wantGross, tt.wantTax)
+			}
+		})
+	}
+}
+
+func TestCalculateGrossIncomeForInvalidTarget(t *testing.T) {
+	if _, err := CalculateGrossIncomeFor("gross", money.NewFromInt(1), TaxInput{}, ""); err == nil {
+		t.Error("CalculateGrossIncomeFor() with invalid target did not return error")
+	}
+}
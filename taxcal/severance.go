@@ -0,0 +1,55 @@
+package taxcal
+
+import (
+	"github.com/windeesel365/assessment-tax/i18n"
+	"github.com/windeesel365/assessment-tax/money"
+)
+
+// SeveranceDeductionPerYear ค่าใช้จ่ายส่วนแรกของเงินได้ที่จ่ายเพราะออกจากงาน ต่อปีที่ทำงาน
+var SeveranceDeductionPerYear = money.NewFromInt(7000)
+
+// SeveranceHalfRate ค่าใช้จ่ายส่วนที่สอง คิดจากเงินที่เหลือหลังหักส่วนแรก
+var SeveranceHalfRate = money.Rate(0.5)
+
+// SeveranceMinYears อายุงานขั้นต่ำที่เลือกแยกคำนวนภาษีได้ (มาตรา 48(5))
+const SeveranceMinYears = 5
+
+// Severance คือเงินชดเชยหรือเงินก้อนจากกองทุนสำรองเลี้ยงชีพที่ได้รับเพราะออกจากงาน
+// ซึ่งเลือกแยกคำนวนภาษีต่างหากจากเงินได้อื่น
+type Severance struct {
+	Amount         money.Money `json:"amount"`
+	YearsOfService int         `json:"yearsOfService"`
+}
+
+// SeveranceResult คือผลการคำนวนภาษีของเงินได้ก้อนเดียว
+type SeveranceResult struct {
+	Amount           money.Money `json:"amount"`
+	ServiceDeduction money.Money `json:"serviceDeduction"`
+	HalfDeduction    money.Money `json:"halfDeduction"`
+	TaxableIncome    money.Money `json:"taxableIncome"`
+	Tax              money.Money `json:"tax"`
+	TaxLevels        []TaxLevel  `json:"taxLevel"`
+}
+
+// CalculateSeverance คำนวนภาษีเงินได้ก้อนเดียว: หัก 7,000 x จำนวนปีที่ทำงาน (ไม่เกินเงินได้)
+// -> หักอีก 50% ของที่เหลือ -> ขั้นบันใดภาษีของปีภาษี โดยไม่มีค่าลดหย่อน, label ของขั้นแสดงเป็นภาษา lang
+func CalculateSeverance(severance Severance, taxYear int, lang i18n.Lang) (SeveranceResult, error) {
+	brackets, err := TaxBracketsFor(taxYear)
+	if err != nil {
+		return SeveranceResult{}, err
+	}
+
+	result := SeveranceResult{Amount: severance.Amount}
+	result.ServiceDeduction = money.Min(SeveranceDeductionPerYear.MulInt(int64(severance.YearsOfService)), severance.Amount)
+	remaining := severance.Amount.Sub(result.ServiceDeduction)
+	result.HalfDeduction = remaining.Mul(SeveranceHalfRate).Round(2)
+	result.TaxableIncome = remaining.Sub(result.HalfDeduction)
+
+	result.TaxLevels = CalculateTaxLevelDetails(result.TaxableIncome, brackets, lang)
+	result.Tax = money.Zero
+	for _, level := range result.TaxLevels {
+		result.Tax = result.Tax.Add(level.Tax)
+	}
+
+	return result, nil
+}
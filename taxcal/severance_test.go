@@ -0,0 +1,86 @@
+package taxcal
+
+import (
+	"testing"
+
+	"github.com/windeesel365/assessment-tax/money"
+)
+
+func TestCalculateSeverance(t *testing.T) {
+	tests := []struct {
+		name                 string
+		severance            Severance
+		wantServiceDeduction money.Money
+		wantTaxableIncome    money.Money
+		wantTax              money.Money
+	}{
+		{
+			name:                 "Ten years of service",
+			severance:            Severance{Amount: money.NewFromInt(1000000), YearsOfService: 10},
+			wantServiceDeduction: money.NewFromInt(70000),
+			// (1,000,000 - 70,000) / 2
+			wantTaxableIncome: money.NewFromInt(465000),
+			wantTax:           money.NewFromInt(31500),
+		},
+		{
+			name:                 "Service deduction capped at amount",
+			severance:            Severance{Amount: money.NewFromInt(50000), YearsOfService: 10},
+			wantServiceDeduction: money.NewFromInt(50000),
+			wantTaxableIncome:    money.Zero,
+			wantTax:              money.Zero,
+		},
+		{
+			name:                 "Twenty years reaches 20% bracket",
+			severance:            Severance{Amount: money.NewFromInt(3000000), YearsOfService: 20},
+			wantServiceDeduction: money.NewFromInt(140000),
+			wantTaxableIncome:    money.NewFromInt(1430000),
+			wantTax:              money.NewFromInt(196000),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CalculateSeverance(tt.severance, 2567, "")
+			if err != nil {
+				t.Fatalf("CalculateSeverance() error = %v", err)
+			}
+			if !got.ServiceDeduction.Equal(tt.wantServiceDeduction) {
+				t.Errorf("ServiceDeduction = %v, want %v", got.ServiceDeduction, tt.wantServiceDeduction)
+			}
+			if !got.TaxableIncome.Equal(tt.wantTaxableIncome) {
+				t.Errorf("TaxableIncome = %v, want %v", got.TaxableIncome, tt.wantTaxableIncome)
+			}
+			if !got.Tax.Equal(tt.wantTax) {
+				t.Errorf("Tax = %v, want %v", got.Tax, tt.wantTax)
+			}
+		})
+	}
+}
+
+func TestCalculateSeveranceUnsupportedYear(t *testing.T) {
+	if _, err := CalculateSeverance(Severance{Amount: money.NewFromInt(100000), YearsOfService: 5}, 2500, ""); err == nil {
+		t.Error("CalculateSeverance() error = nil, want unsupported taxYear error")
+	}
+}
+
+func TestCalculateWithSeverance(t *testing.T) {
+	result, err := Calculate(TaxInput{
+		TotalIncome: money.NewFromInt(500000),
+		WHT:         money.NewFromInt(30000),
+		Severance:   &Severance{Amount: money.NewFromInt(1000000), YearsOfService: 10},
+	})
+	if err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+
+	// ภาษีทั้งปี 29,000 + ภาษีเงินได้ก้อนเดียว 31,500 - wht 30,000
+	if want := money.NewFromInt(60500); !result.Tax.Equal(want) {
+		t.Errorf("Tax = %v, want %v", result.Tax, want)
+	}
+	if want := money.NewFromInt(30500); !result.TaxPayable.Equal(want) {
+		t.Errorf("TaxPayable = %v, want %v", result.TaxPayable, want)
+	}
+	if want := money.NewFromInt(440000); !result.TaxableIncome.Equal(want) {
+		t.Errorf("TaxableIncome = %v, want %v, severance must not change annual taxable income", result.TaxableIncome, want)
+	}
+}
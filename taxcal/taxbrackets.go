@@ -0,0 +1,87 @@
+package taxcal
+
+import (
+	"sort"
+
+	"github.com/shopspring/decimal"
+	"github.com/windeesel365/assessment-tax/i18n"
+	"github.com/windeesel365/assessment-tax/money"
+)
+
+// DefaultTaxYear ปีภาษี (พ.ศ.) ที่ใช้เมื่อ client ไม่ได้ระบุ taxYear มา
+const DefaultTaxYear = 2567
+
+// TaxBracket คือขั้นบันใดภาษีหนึ่งขั้น
+// Min ของแต่ละขั้นลงท้ายด้วย 1 ตามรูปแบบ taxLevel และ Max เป็น -1 เพื่อแสดง no upper limit ทางบวก
+type TaxBracket struct {
+	Min  money.Money
+	Max  money.Money
+	Rate decimal.Decimal
+}
+
+// bracket ย่อการสร้าง TaxBracket จากจำนวนเต็มบาทและอัตราภาษี
+func bracket(min, max int64, rate float64) TaxBracket {
+	return TaxBracket{Min: money.NewFromInt(min), Max: money.NewFromInt(max), Rate: money.Rate(rate)}
+}
+
+// hasUpperLimit เป็น false สำหรับขั้นสุดท้ายที่ Max เป็น -1
+func (b TaxBracket) hasUpperLimit() bool {
+	return !b.Max.IsNegative()
+}
+
+// ขั้นบันใดภาษีตามประกาศกรมสรรพากร ใช้ตั้งแต่ปีภาษี 2560
+var taxBrackets2560 = []TaxBracket{
+	bracket(0, 150000, 0),
+	bracket(150001, 300000, 0.05),
+	bracket(300001, 500000, 0.1),
+	bracket(500001, 750000, 0.15),
+	bracket(750001, 1000000, 0.2),
+	bracket(1000001, 2000000, 0.25),
+	bracket(2000001, 5000000, 0.3),
+	bracket(5000001, -1, 0.35),
+}
+
+// ขั้นบันใดภาษีปี 2567 ตาม requirement ของ K-Tax
+var taxBrackets2567 = []TaxBracket{
+	bracket(0, 150000, 0),
+	bracket(150001, 500000, 0.1),
+	bracket(500001, 1000000, 0.15),
+	bracket(1000001, 2000000, 0.2),
+	bracket(2000001, -1, 0.35),
+}
+
+// taxBracketTables เก็บตารางขั้นบันใดภาษีแยกตามปีภาษี (พ.ศ.)
+// ทั้ง tax รวมและ taxLevel ต้องมาจากตารางเดียวกันนี้เสมอ
+var taxBracketTables = map[int][]TaxBracket{
+	2560: taxBrackets2560,
+	2561: taxBrackets2560,
+	2562: taxBrackets2560,
+	2563: taxBrackets2560,
+	2564: taxBrackets2560,
+	2565: taxBrackets2560,
+	2566: taxBrackets2560,
+	2567: taxBrackets2567,
+}
+
+// TaxBracketsFor หาตารางขั้นบันใดภาษีของปีภาษีที่ระบุ
+// taxYear เป็น 0 หมายถึงใช้ DefaultTaxYear
+func TaxBracketsFor(taxYear int) ([]TaxBracket, error) {
+	if taxYear == 0 {
+		taxYear = DefaultTaxYear
+	}
+	brackets, ok := taxBracketTables[taxYear]
+	if !ok {
+		return nil, i18n.Errorf("taxYear %d is not supported, supported tax years are %v", taxYear, SupportedTaxYears())
+	}
+	return brackets, nil
+}
+
+// SupportedTaxYears คืนปีภาษีที่มีตารางขั้นบันใดภาษี เรียงจากน้อยไปมาก
+func SupportedTaxYears() []int {
+	years := make([]int, 0, len(taxBracketTables))
+	for year := range taxBracketTables {
+		years = append(years, year)
+	}
+	sort.Ints(years)
+	return years
+}
@@ -0,0 +1,45 @@
+package taxcal
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTaxBracketsFor(t *testing.T) {
+	tests := []struct {
+		name     string
+		taxYear  int
+		expected []TaxBracket
+		wantErr  bool
+	}{
+		{"Default year", 0, taxBrackets2567, false},
+		{"Year 2567", 2567, taxBrackets2567, false},
+		{"Year 2566", 2566, taxBrackets2560, false},
+		{"Unsupported year", 2550, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			brackets, err := TaxBracketsFor(tt.taxYear)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TaxBracketsFor(%d) error = %v, wantErr %v", tt.taxYear, err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(brackets, tt.expected) {
+				t.Errorf("TaxBracketsFor(%d) = %v, want %v", tt.taxYear, brackets, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSupportedTaxYears(t *testing.T) {
+	years := SupportedTaxYears()
+	if len(years) != len(taxBracketTables) {
+		t.Fatalf("SupportedTaxYears() returned %d years, want %d", len(years), len(taxBracketTables))
+	}
+	for i := 1; i < len(years); i++ {
+		if years[i-1] >= years[i] {
+			t.Errorf("SupportedTaxYears() = %v, want ascending order", years)
+		}
+	}
+}
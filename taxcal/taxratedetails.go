@@ -0,0 +1,53 @@
+package taxcal
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/windeesel365/assessment-tax/i18n"
+	"github.com/windeesel365/assessment-tax/money"
+)
+
+// TaxRateDetails คืออัตราภาษีที่แท้จริง และขั้นบันใดภาษีสุดท้ายของผลการคำนวน
+type TaxRateDetails struct {
+	TaxableIncome   money.Money
+	TotalDeductions money.Money
+	// EffectiveTaxRate คือภาษีที่เสียจริงหารเงินได้พึงประเมิน ปัดเศษ 4 ตำแหน่ง
+	EffectiveTaxRate decimal.Decimal
+	MarginalBracket  string
+	MarginalRate     decimal.Decimal
+	// AmountToNextBracket เป็น nil เมื่ออยู่ขั้นสุดท้ายซึ่งไม่มีขั้นถัดไป
+	AmountToNextBracket *money.Money
+}
+
+// CalculateTaxRateDetails หา effective rate, marginal bracket และเงินได้สุทธิที่เหลือจนถึงขั้นถัดไป
+// จาก TaxResult ของ Calculate, MarginalBracket แสดงเป็นภาษา lang
+func CalculateTaxRateDetails(result TaxResult, lang i18n.Lang) (TaxRateDetails, error) {
+	brackets, err := TaxBracketsFor(result.TaxYear)
+	if err != nil {
+		return TaxRateDetails{}, err
+	}
+
+	marginal := MarginalBracket(result.TaxableIncome, brackets)
+	details := TaxRateDetails{
+		TaxableIncome:    result.TaxableIncome,
+		TotalDeductions:  result.Expenses.Add(result.TotalDeductions),
+		EffectiveTaxRate: decimal.Zero,
+		MarginalBracket:  formatLevelString(marginal, lang),
+		MarginalRate:     marginal.Rate,
+	}
+
+	// Tax รวมภาษีของเงินได้ก้อนเดียวแล้ว จึงรวมเงินได้ก้อนเดียวเป็นเงินได้พึงประเมินด้วย
+	grossIncome := result.GrossIncome
+	if result.Severance != nil {
+		grossIncome = grossIncome.Add(result.Severance.Amount)
+	}
+	if grossIncome.IsPositive() {
+		details.EffectiveTaxRate = result.Tax.Decimal().Div(grossIncome.Decimal()).Round(4)
+	}
+
+	if marginal.hasUpperLimit() {
+		toNext := marginal.Max.Sub(result.TaxableIncome)
+		details.AmountToNextBracket = &toNext
+	}
+
+	return details, nil
+}
@@ -0,0 +1,73 @@
+package taxcal
+
+import (
+	"testing"
+
+	"github.com/windeesel365/assessment-tax/money"
+)
+
+func TestCalculateTaxRateDetails(t *testing.T) {
+	tests := []struct {
+		name              string
+		input             TaxInput
+		wantEffectiveRate float64
+		wantBracket       string
+		wantMarginalRate  float64
+		wantToNext        *money.Money
+	}{
+		{
+			name:              "Below taxable threshold",
+			input:             TaxInput{TotalIncome: money.NewFromInt(150000)},
+			wantEffectiveRate: 0,
+			wantBracket:       "0-150,000",
+			wantMarginalRate:  0,
+			wantToNext:        moneyPtr(money.NewFromInt(60000)),
+		},
+		{
+			name:              "Middle bracket",
+			input:             TaxInput{TotalIncome: money.NewFromInt(500000)},
+			wantEffectiveRate: 0.058,
+			wantBracket:       "150,001-500,000",
+			wantMarginalRate:  0.10,
+			wantToNext:        moneyPtr(money.NewFromInt(60000)),
+		},
+		{
+			name:              "Top bracket has no next bracket",
+			input:             TaxInput{TotalIncome: money.NewFromInt(3000000)},
+			wantEffectiveRate: 0.213,
+			wantBracket:       "2,000,001 ขึ้นไป",
+			wantMarginalRate:  0.35,
+			wantToNext:        nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Calculate(tt.input)
+			if err != nil {
+				t.Fatalf("Calculate() error = %v", err)
+			}
+			got, err := CalculateTaxRateDetails(result, "")
+			if err != nil {
+				t.Fatalf("CalculateTaxRateDetails() error = %v", err)
+			}
+			if !got.EffectiveTaxRate.Equal(money.Rate(tt.wantEffectiveRate)) {
+				t.Errorf("EffectiveTaxRate = %v, want %v", got.EffectiveTaxRate, tt.wantEffectiveRate)
+			}
+			if got.MarginalBracket != tt.wantBracket {
+				t.Errorf("MarginalBracket = %q, want %q", got.MarginalBracket, tt.wantBracket)
+			}
+			if !got.MarginalRate.Equal(money.Rate(tt.wantMarginalRate)) {
+				t.Errorf("MarginalRate = %v, want %v", got.MarginalRate, tt.wantMarginalRate)
+			}
+			if (got.AmountToNextBracket == nil) != (tt.wantToNext == nil) ||
+				(got.AmountToNextBracket != nil && !got.AmountToNextBracket.Equal(*tt.wantToNext)) {
+				t.Errorf("AmountToNextBracket = %v, want %v", got.AmountToNextBracket, tt.wantToNext)
+			}
+		})
+	}
+}
+
+func moneyPtr(m money.Money) *money.Money {
+	return &m
+}
@@ -0,0 +1,277 @@
+package taxcal
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	"github.com/windeesel365/assessment-tax/i18n"
+	"github.com/windeesel365/assessment-tax/money"
+)
+
+// ชนิดของขั้นตอนใน Trace เรียงตามลำดับที่ Calculate ทำ
+const (
+	TraceStepIncome          = "income"
+	TraceStepGrossIncome     = "grossIncome"
+	TraceStepNetIncome       = "netIncome"
+	TraceStepAllowance       = "allowance"
+	TraceStepFamilyAllowance = "familyAllowance"
+	TraceStepTaxableIncome   = "taxableIncome"
+	TraceStepTaxBracket      = "taxBracket"
+	TraceStepTaxMethod       = "taxMethod"
+	TraceStepSeverance       = "severance"
+	TraceStepWHT             = "wht"
+	TraceStepLateFiling      = "lateFiling"
+	TraceStepDividend        = "dividend"
+)
+
+// TraceStep คือการคำนวนหนึ่งขั้น: Input ผ่าน Rule ได้ Output
+// Limit คือเพดานที่ใช้จริง ณ ตอนคำนวน (nil คือไม่มีเพดาน)
+type TraceStep struct {
+	Step   string       `json:"step"`
+	Name   string       `json:"name,omitempty"`
+	Input  money.Money  `json:"input"`
+	Rule   string       `json:"rule"`
+	Limit  *money.Money `json:"limit,omitempty"`
+	Output money.Money  `json:"output"`
+}
+
+// Trace บันทึกขั้นตอนการคำนวนตามลำดับ สำหรับตรวจสอบที่มาของตัวเลข
+// ส่งผ่าน TaxInput.Trace, *Trace ที่เป็น nil เรียกทุก method ได้โดยไม่บันทึกอะไร
+type Trace struct {
+	Steps []TraceStep
+	// lang คือภาษาของ Rule ซึ่ง Calculate กำหนดจาก TaxInput.Lang
+	lang i18n.Lang
+}
+
+// Record เพิ่มขั้นตอนต่อท้าย
+func (t *Trace) Record(step TraceStep) {
+	if t == nil {
+		return
+	}
+	t.Steps = append(t.Steps, step)
+}
+
+// enabled เป็น false เมื่อไม่ต้องบันทึก ใช้ข้ามการประกอบ Rule ที่ไม่ได้ใช้
+func (t *Trace) enabled() bool {
+	return t != nil
+}
+
+// setLang กำหนดภาษาของ Rule
+func (t *Trace) setLang(lang i18n.Lang) {
+	if t == nil {
+		return
+	}
+	t.lang = lang
+}
+
+// rulef ประกอบ Rule แบบ fmt.Sprintf เป็นภาษาของ Trace, t ที่เป็น nil คืนข้อความว่าง
+func (t *Trace) rulef(format string, args ...interface{}) string {
+	if t == nil {
+		return ""
+	}
+	return i18n.Msg(format, args...).In(t.lang)
+}
+
+// child คืน Trace ใหม่ภาษาเดียวกันสำหรับการคำนวนย่อย หรือ nil เมื่อ t เป็น nil
+func (t *Trace) child() *Trace {
+	if t == nil {
+		return nil
+	}
+	return &Trace{lang: t.lang}
+}
+
+// append ต่อขั้นตอนของการคำนวนย่อยที่เลือกใช้
+func (t *Trace) append(other *Trace) {
+	if t == nil || other == nil {
+		return
+	}
+	t.Steps = append(t.Steps, other.Steps...)
+}
+
+// traceIncomes บันทึกการแปลงสกุลเงินและค่าใช้จ่ายของเงินได้แต่ละรายการ
+func traceIncomes(trace *Trace, incomes []IncomeExpense) {
+	if !trace.enabled() {
+		return
+	}
+	for _, income := range incomes {
+		name := income.Section
+		if income.SubType != "" {
+			name += " " + income.SubType
+		}
+
+		var rules []string
+		if conversion := income.Conversion; conversion != nil {
+			rules = append(rules, fmt.Sprintf("%s %s x %s", formatTraceAmount(conversion.Amount), conversion.Currency, conversion.Rate))
+		}
+		var limit *money.Money
+		section, _ := LookupIncomeSection(income.Section)
+		rate := section.Rates[income.SubType]
+		if flat := income.Amount.Mul(rate); income.Expense.Equal(flat) {
+			rules = append(rules, trace.rulef("expense %s", formatTraceRate(rate)))
+		} else if income.Expense.LessThan(flat) && section.Cap != nil {
+			expense := income.Expense
+			limit = &expense
+			rule := trace.rulef("expense %s capped at %s", formatTraceRate(rate), formatTraceAmount(*section.Cap))
+			if section.CapGroup != "" {
+				rule = trace.rulef("expense %s capped at %s shared by %s", formatTraceRate(rate), formatTraceAmount(*section.Cap),
+					strings.Join(capGroupSections(section.CapGroup), ", "))
+			}
+			rules = append(rules, rule)
+		} else {
+			rules = append(rules, trace.rulef("actual expenses"))
+		}
+
+		trace.Record(TraceStep{
+			Step:   TraceStepIncome,
+			Name:   name,
+			Input:  income.Amount,
+			Rule:   strings.Join(rules, ", "),
+			Limit:  limit,
+			Output: income.NetIncome,
+		})
+	}
+}
+
+// capGroupSections คืน section ที่ใช้เพดานค่าใช้จ่ายร่วมกัน
+func capGroupSections(capGroup string) []string {
+	var sections []string
+	for _, section := range incomeSections {
+		if section.CapGroup == capGroup {
+			sections = append(sections, section.Section)
+		}
+	}
+	return sections
+}
+
+// traceAllowances บันทึกค่าลดหย่อนที่ส่งมาหรือมี Default พร้อมเพดานที่ใช้จริงหลังเพดานรวมของ group (AppliedAllowance.Limit)
+// income คือเงินได้ที่ใช้แสดงเพดานของแต่ละชนิดใน Rule, netIncomeCaps คือผลของ ApplyNetIncomeCaps
+func traceAllowances(trace *Trace, claims []AllowanceClaim, applied []AppliedAllowance, income money.Money, netIncomeCaps map[string]money.Money) {
+	if !trace.enabled() {
+		return
+	}
+	claimed := map[string]bool{}
+	for _, claim := range claims {
+		claimed[claim.AllowanceType] = true
+	}
+
+	for i, rule := range allowanceRegistry {
+		allowance := applied[i]
+		if allowance.Claimed.IsZero() && allowance.Amount.IsZero() {
+			continue
+		}
+
+		var rules []string
+		if !claimed[rule.Name] {
+			rules = append(rules, trace.rulef("default"))
+		}
+		if allowance.Substituted && rule.ClaimedRule != nil {
+			rules = append(rules, rule.ClaimedRule().In(trace.lang))
+		}
+		if rule.multiplier() > 1 {
+			rules = append(rules, fmt.Sprintf("x%d", rule.multiplier()))
+		}
+		if limit, ok := rule.limit(income); ok {
+			rules = append(rules, trace.rulef("cap %s", formatTraceAmount(limit)))
+		}
+		if group, found := lookupAllowanceGroup(rule.Group); found {
+			switch {
+			case group.NetIncomeCap != nil:
+				rules = append(rules, trace.rulef("group %s cap %s of net income after other allowances = %s", group.Name, formatTraceRate(DonationIncomeRate), formatTraceAmount(netIncomeCaps[group.Name])))
+			case group.Cap != nil:
+				rules = append(rules, trace.rulef("group %s cap %s", group.Name, formatTraceAmount(group.Cap())))
+			}
+		}
+		if len(rules) == 0 {
+			rules = append(rules, trace.rulef("no cap"))
+		}
+
+		// เพดานอ่านจากที่ ApplyAllowances ใช้จริง ไม่คิดซ้ำจากกฎ
+		trace.Record(TraceStep{
+			Step:   TraceStepAllowance,
+			Name:   rule.Name,
+			Input:  allowance.Claimed,
+			Rule:   strings.Join(rules, ", "),
+			Limit:  allowance.Limit,
+			Output: allowance.Amount,
+		})
+	}
+}
+
+// traceFamilyAllowances บันทึกค่าลดหย่อนครอบครัวแต่ละชนิด
+func traceFamilyAllowances(trace *Trace, allowances []FamilyAllowance) {
+	for _, allowance := range allowances {
+		trace.Record(TraceStep{
+			Step:   TraceStepFamilyAllowance,
+			Name:   allowance.AllowanceType,
+			Input:  allowance.Amount,
+			Rule:   trace.rulef("%d person(s)", allowance.Count),
+			Output: allowance.Amount,
+		})
+	}
+}
+
+// traceTaxBrackets บันทึกเงินได้สุทธิส่วนที่อยู่ในแต่ละขั้นบันใด และภาษีของขั้นนั้น
+func traceTaxBrackets(trace *Trace, step string, taxableIncome money.Money, brackets []TaxBracket, levels []TaxLevel) {
+	if !trace.enabled() {
+		return
+	}
+	for i, bracket := range brackets {
+		upper := taxableIncome
+		if bracket.hasUpperLimit() {
+			upper = money.Min(upper, bracket.Max)
+		}
+		// ขอบล่างของขั้นลงท้าย 1 ยกเว้นขั้นแรกที่เริ่มจาก 0
+		lower := money.Max(bracket.Min.Sub(money.NewFromInt(1)), money.Zero)
+		portion := money.Max(upper.Sub(lower), money.Zero)
+		trace.Record(TraceStep{
+			Step:   step,
+			Name:   levels[i].Level,
+			Input:  portion,
+			Rule:   fmt.Sprintf("%s x %s", formatTraceAmount(portion), formatTraceRate(bracket.Rate)),
+			Output: levels[i].Tax,
+		})
+	}
+}
+
+// traceSeverance บันทึกค่าใช้จ่ายของเงินได้ก้อนเดียว และขั้นบันใดภาษีที่แยกคำนวน
+func traceSeverance(trace *Trace, severance Severance, result SeveranceResult, brackets []TaxBracket) {
+	if !trace.enabled() {
+		return
+	}
+	remaining := result.Amount.Sub(result.ServiceDeduction)
+	trace.Record(TraceStep{
+		Step:   TraceStepSeverance,
+		Name:   "serviceDeduction",
+		Input:  result.Amount,
+		Rule:   trace.rulef("less %s x %d year(s), not exceeding amount", formatTraceAmount(SeveranceDeductionPerYear), severance.YearsOfService),
+		Output: remaining,
+	})
+	trace.Record(TraceStep{
+		Step:   TraceStepSeverance,
+		Name:   "halfDeduction",
+		Input:  remaining,
+		Rule:   trace.rulef("less %s", formatTraceRate(SeveranceHalfRate)),
+		Output: result.TaxableIncome,
+	})
+	traceTaxBrackets(trace, TraceStepSeverance, result.TaxableIncome, brackets, result.TaxLevels)
+}
+
+// formatTraceAmount แสดงจำนวนเงินแบบมี comma, เศษสตางค์แสดง 2 ตำแหน่ง
+func formatTraceAmount(amount money.Money) string {
+	sign := ""
+	if amount.IsNegative() {
+		sign, amount = "-", amount.Neg()
+	}
+	rounded := amount.Round(2)
+	formatted := formatAmount(rounded)
+	if satang := rounded.Sub(money.NewFromInt(rounded.IntPart())); !satang.IsZero() {
+		formatted += satang.StringFixed(2)[1:]
+	}
+	return sign + formatted
+}
+
+// formatTraceRate แสดงอัตราเป็นเปอร์เซ็นต์ เช่น 0.1 เป็น 10%
+func formatTraceRate(rate decimal.Decimal) string {
+	return rate.Mul(decimal.NewFromInt(100)).String() + "%"
+}
@@ -0,0 +1,158 @@
+package taxcal
+
+import (
+	"testing"
+
+	"github.com/windeesel365/assessment-tax/i18n"
+	"github.com/windeesel365/assessment-tax/money"
+)
+
+func TestTraceNilRecorder(t *testing.T) {
+	var trace *Trace
+	trace.Record(TraceStep{Step: TraceStepWHT})
+	trace.append(&Trace{Steps: []TraceStep{{Step: TraceStepWHT}}})
+	if trace.child() != nil {
+		t.Error("child() of nil Trace must be nil")
+	}
+
+	// Calculate ไม่บันทึกอะไรเมื่อไม่ได้ส่ง Trace
+	if _, err := Calculate(TaxInput{TotalIncome: money.NewFromInt(500000)}); err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+}
+
+func TestCalculateTrace(t *testing.T) {
+	trace := &Trace{}
+	_, err := Calculate(TaxInput{
+		TotalIncome: money.NewFromInt(500000),
+		Allowances: []AllowanceClaim{
+			{AllowanceType: "k-receipt", Amount: money.NewFromInt(200000)},
+			{AllowanceType: "donation", Amount: money.NewFromInt(100000)},
+		},
+		Trace: trace,
+	})
+	if err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+
+	limit := func(amount int64) *money.Money {
+		m := money.NewFromInt(amount)
+		return &m
+	}
+	want := []struct {
+		step   string
+		name   string
+		input  money.Money
+		limit  *money.Money
+		output money.Money
+	}{
+		{TraceStepGrossIncome, "", money.NewFromInt(500000), nil, money.NewFromInt(500000)},
+		{TraceStepNetIncome, "", money.NewFromInt(500000), nil, money.NewFromInt(500000)},
+		{TraceStepAllowance, "personal", money.NewFromInt(60000), limit(100000), money.NewFromInt(60000)},
+		// เงินบริจาคไม่เกิน 10% ของ 500,000 - 60,000 - 50,000
+		{TraceStepAllowance, "donation", money.NewFromInt(100000), limit(39000), money.NewFromInt(39000)},
+		{TraceStepAllowance, "k-receipt", money.NewFromInt(200000), limit(50000), money.NewFromInt(50000)},
+		{TraceStepTaxableIncome, "", money.NewFromInt(500000), nil, money.NewFromInt(351000)},
+		{TraceStepTaxBracket, "0-150,000", money.NewFromInt(150000), nil, money.Zero},
+		{TraceStepTaxBracket, "150,001-500,000", money.NewFromInt(201000), nil, money.NewFromInt(20100)},
+		{TraceStepTaxBracket, "500,001-1,000,000", money.Zero, nil, money.Zero},
+		{TraceStepTaxBracket, "1,000,001-2,000,000", money.Zero, nil, money.Zero},
+		{TraceStepTaxBracket, "2,000,001 ขึ้นไป", money.Zero, nil, money.Zero},
+		{TraceStepWHT, "", money.NewFromInt(20100), nil, money.NewFromInt(20100)},
+	}
+
+	if len(trace.Steps) != len(want) {
+		t.Fatalf("len(Steps) = %d, want %d: %+v", len(trace.Steps), len(want), trace.Steps)
+	}
+	for i, w := range want {
+		got := trace.Steps[i]
+		if got.Step != w.step || got.Name != w.name {
+			t.Errorf("Steps[%d] = %s %s, want %s %s", i, got.Step, got.Name, w.step, w.name)
+		}
+		if !got.Input.Equal(w.input) || !got.Output.Equal(w.output) {
+			t.Errorf("Steps[%d] %s input, output = %v, %v; want %v, %v", i, got.Name, got.Input, got.Output, w.input, w.output)
+		}
+		if (got.Limit == nil) != (w.limit == nil) || (got.Limit != nil && !got.Limit.Equal(*w.limit)) {
+			t.Errorf("Steps[%d] %s limit = %v, want %v", i, got.Name, got.Limit, w.limit)
+		}
+		if got.Rule == "" {
+			t.Errorf("Steps[%d] %s rule is empty", i, got.Name)
+		}
+	}
+}
+
+func TestCalculateTraceDividends(t *testing.T) {
+	trace := &Trace{}
+	_, err := Calculate(TaxInput{
+		TotalIncome: money.NewFromInt(500000),
+		Dividends:   []Dividend{{Amount: money.NewFromInt(100000)}},
+		Trace:       trace,
+	})
+	if err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+
+	// บันทึกเฉพาะวิธีที่เลือก (include) ซึ่งมีเงินได้ 40(4) หนึ่งรายการ
+	incomes := 0
+	for _, step := range trace.Steps {
+		if step.Step == TraceStepIncome {
+			incomes++
+		}
+	}
+	if incomes != 1 {
+		t.Errorf("income steps = %d, want 1", incomes)
+	}
+	last := trace.Steps[len(trace.Steps)-1]
+	if last.Step != TraceStepDividend || last.Name != DividendMethodInclude || !last.Output.Equal(money.NewFromInt(19750)) {
+		t.Errorf("last step = %+v, want dividend include 19750", last)
+	}
+}
+
+func TestCalculateTraceLang(t *testing.T) {
+	tests := []struct {
+		lang      i18n.Lang
+		wantRule  string
+		wantLevel string
+	}{
+		{"", "less wht 0, negative is taxRefund", "2,000,001 ขึ้นไป"},
+		{i18n.English, "less wht 0, negative is taxRefund", "2,000,001 and over"},
+		{i18n.Thai, "หักภาษี ณ ที่จ่าย 0, ติดลบคือ taxRefund", "2,000,001 ขึ้นไป"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.lang), func(t *testing.T) {
+			trace := &Trace{}
+			result, err := Calculate(TaxInput{TotalIncome: money.NewFromInt(500000), Trace: trace, Lang: tt.lang})
+			if err != nil {
+				t.Fatalf("Calculate() error = %v", err)
+			}
+			if got := trace.Steps[len(trace.Steps)-1].Rule; got != tt.wantRule {
+				t.Errorf("wht rule = %q, want %q", got, tt.wantRule)
+			}
+			if got := result.TaxLevels[len(result.TaxLevels)-1].Level; got != tt.wantLevel {
+				t.Errorf("last level = %q, want %q", got, tt.wantLevel)
+			}
+		})
+	}
+}
+
+func TestFormatTraceAmount(t *testing.T) {
+	tests := []struct {
+		amount money.Money
+		want   string
+	}{
+		{money.NewFromInt(100), "100"},
+		{money.NewFromInt(39000), "39,000"},
+		{money.NewFromInt(-1500), "-1,500"},
+		{money.NewFromInt(-100), "-100"},
+		{money.NewFromInt(1234567).Add(money.NewFromInt(1).DivInt(2)), "1,234,567.50"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := formatTraceAmount(tt.amount); got != tt.want {
+				t.Errorf("formatTraceAmount(%v) = %q, want %q", tt.amount, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package validityguard
+
+import "github.com/windeesel365/assessment-tax/i18n"
+
+// คำแปลภาษาไทยของ error ใน package validityguard
+func init() {
+	i18n.Register(i18n.Thai, map[string]string{
+		// body ของ admin endpoint
+		"Please provide input data": "กรุณาใส่ข้อมูล",
+		"Invalid input":             "ข้อมูลไม่ถูกต้อง",
+		"Invalid input format. Please check the input format again":                                                     "รูปแบบข้อมูลไม่ถูกต้อง กรุณาตรวจสอบรูปแบบข้อมูลอีกครั้ง",
+		"Invalid input. Please ensure you enter only one amount, corresponding to setting upper limit of k-receipt.":    "ข้อมูลไม่ถูกต้อง กรุณาใส่ amount เพียงค่าเดียว สำหรับกำหนดเพดานของ k-receipt",
+		"Invalid input. Please ensure you enter only one amount, corresponding to setting value of personal deduction.": "ข้อมูลไม่ถูกต้อง กรุณาใส่ amount เพียงค่าเดียว สำหรับกำหนดค่าลดหย่อนส่วนตัว",
+		"Please ensure Personal Deduction amount does not exceed THB 100,000.":                                          "ค่าลดหย่อนส่วนตัวต้องไม่เกิน 100,000 บาท",
+		"Please ensure Personal Deduction must be more than THB 10000.":                                                 "ค่าลดหย่อนส่วนตัวต้องมากกว่า 10,000 บาท",
+		"Please ensure kReceipt UpperLimit does not exceed THB 100,000.":                                                "เพดานของ kReceipt ต้องไม่เกิน 100,000 บาท",
+		"Please ensure kReceipt UpperLimit must be more than THB 0.":                                                    "เพดานของ kReceipt ต้องมากกว่า 0 บาท",
+		"Please ensure currency is a 3-letter ISO 4217 code other than THB, such as USD.":                               "currency ต้องเป็นรหัส ISO 4217 3 ตัวอักษรที่ไม่ใช่ THB เช่น USD",
+		"Please ensure taxYear is a supported tax year.":                                                                "taxYear ต้องเป็นปีภาษีที่รองรับ",
+		"Please ensure date is within the tax year.":                                                                    "date ต้องอยู่ในปีภาษี",
+		"Please ensure rate is more than 0.":                                                                            "rate ต้องมากกว่า 0",
+
+		// tax request
+		"totalIncome must be a non-negative value": "totalIncome ต้องไม่ติดลบ",
+		"wht must be a non-negative value":         "wht ต้องไม่ติดลบ",
+		"please ensure that Withholding Tax(WHT) not exceed your total income. Let us know if you need any help": "ภาษีหัก ณ ที่จ่าย (WHT) ต้องไม่เกินเงินได้ทั้งหมด หากต้องการความช่วยเหลือกรุณาติดต่อเรา",
+		"at least one allowance must be provided":            "ต้องมีค่าลดหย่อนอย่างน้อยหนึ่งรายการ",
+		"please ensure that allowanceType inputed correctly": "กรุณาตรวจสอบว่ากรอก allowanceType ถูกต้อง",
+		"amount for %s must be a non-negative value":         "amount ของ %s ต้องไม่ติดลบ",
+		"amount must be a non-negative value":                "amount ต้องไม่ติดลบ",
+
+		// incomes
+		"%s.section must be one of: %s":                            "%s.section ต้องเป็นหนึ่งใน: %s",
+		"%s.subType %q is not valid for income section %s":         "%s.subType %q ใช้กับเงินได้ %s ไม่ได้",
+		"%s.amount must be a non-negative value":                   "%s.amount ต้องไม่ติดลบ",
+		"%s.currency must be a 3-letter ISO 4217 code such as USD": "%s.currency ต้องเป็นรหัส ISO 4217 3 ตัวอักษร เช่น USD",
+		"%s.date requires currency":                                "%s.date ต้องระบุ currency ด้วย",
+		"%s.actualExpenses requires expenseMethod %s":              "%s.actualExpenses ต้องใช้คู่กับ expenseMethod %s",
+		"%s income section %s does not allow actual expenses":      "%s เงินได้ %s หักค่าใช้จ่ายตามจริงไม่ได้",
+		"%s.actualExpenses must be a non-negative value":           "%s.actualExpenses ต้องไม่ติดลบ",
+		"%s.actualExpenses must not exceed amount":                 "%s.actualExpenses ต้องไม่เกิน amount",
+		"%s.expenseMethod must be %s or %s":                        "%s.expenseMethod ต้องเป็น %s หรือ %s",
+
+		// วันที่ยื่นแบบ เงินปันผล และเงินได้ก้อนเดียว
+		"paymentDate requires filingDate":                                           "paymentDate ต้องระบุ filingDate ด้วย",
+		"filingDate must be after the end of tax year, on or after %d-01-01":        "filingDate ต้องหลังสิ้นปีภาษี คือตั้งแต่ %d-01-01",
+		"paymentDate must not be before filingDate":                                 "paymentDate ต้องไม่ก่อน filingDate",
+		"dividends[%d].amount must be a non-negative value":                         "dividends[%d].amount ต้องไม่ติดลบ",
+		"dividends[%d].corporateTaxRate must be between 0 and 99":                   "dividends[%d].corporateTaxRate ต้องอยู่ระหว่าง 0 ถึง 99",
+		"dividendMethod requires dividends":                                         "dividendMethod ต้องมี dividends ด้วย",
+		"dividendMethod must be %s or %s":                                           "dividendMethod ต้องเป็น %s หรือ %s",
+		"severance.amount must be greater than 0":                                   "severance.amount ต้องมากกว่า 0",
+		"severance.yearsOfService must be at least %d years to be taxed separately": "severance.yearsOfService ต้องอย่างน้อย %d ปีจึงจะแยกคำนวนภาษีได้",
+
+		// dependants
+		"children[%d].birthYear must be a Buddhist year not later than tax year %d": "children[%d].birthYear ต้องเป็นปีพุทธศักราชที่ไม่เกินปีภาษี %d",
+		"parental care can be claimed for at most %d parents":                       "ลดหย่อนบิดามารดาได้ไม่เกิน %d คน",
+		"parents[%d] must be aged %d or over to claim parental care":                "parents[%d] ต้องอายุ %d ปีขึ้นไปจึงจะลดหย่อนได้",
+		"parents[%d].income must be a non-negative value":                           "parents[%d].income ต้องไม่ติดลบ",
+		"parents[%d] income must not exceed %s THB to claim parental care":          "parents[%d] ต้องมีเงินได้ไม่เกิน %s บาทจึงจะลดหย่อนได้",
+		"disabledDependants must be a non-negative value":                           "disabledDependants ต้องไม่ติดลบ",
+
+		// reverse, payroll, compare และ batch
+		"target must be %s or %s":                                         "target ต้องเป็น %s หรือ %s",
+		"section %q is not a valid income section":                        "section %q ไม่ใช่ประเภทเงินได้ที่ถูกต้อง",
+		"salaries must contain at least one salary period":                "salaries ต้องมีอย่างน้อยหนึ่งช่วงเงินเดือน",
+		"fromMonth must be between 1 and %d":                              "fromMonth ต้องอยู่ระหว่าง 1 ถึง %d",
+		"salaries must be ordered by fromMonth without duplicates":        "salaries ต้องเรียงตาม fromMonth และไม่ซ้ำกัน",
+		"salary amount must be a non-negative value":                      "เงินเดือนต้องไม่ติดลบ",
+		"bonus month must be between 1 and %d":                            "เดือนของโบนัสต้องอยู่ระหว่าง 1 ถึง %d",
+		"bonus amount must be greater than 0":                             "โบนัสต้องมากกว่า 0",
+		"scenarios must contain between 2 and %d scenarios":               "scenarios ต้องมี 2 ถึง %d รายการ",
+		"scenarios[%d].name is required":                                  "ต้องระบุ scenarios[%d].name",
+		"scenarios[%d].name %q is redundant, please check and fill again": "scenarios[%d].name %q ซ้ำ กรุณาตรวจสอบแล้วกรอกใหม่",
+		"scenarios[%d].request is required":                               "ต้องระบุ scenarios[%d].request",
+		"batch must contain at least one tax request":                     "batch ต้องมีคำขออย่างน้อยหนึ่งรายการ",
+		"batch must contain at most %d tax requests, got %d":              "batch มีคำขอได้ไม่เกิน %d รายการ แต่ส่งมา %d รายการ",
+	})
+}
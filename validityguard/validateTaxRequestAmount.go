@@ -1,64 +1,108 @@
 package validityguard
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/windeesel365/assessment-tax/apierror"
+	"github.com/windeesel365/assessment-tax/money"
+	"github.com/windeesel365/assessment-tax/taxcal"
+)
 
 // data structure pattern ที่ user client request
 type TaxRequest struct {
-	TotalIncome float64 `json:"totalIncome"`
-	WHT         float64 `json:"wht"`
-	Allowances  []struct {
-		AllowanceType string  `json:"allowanceType"`
-		Amount        float64 `json:"amount"`
-	} `json:"allowances"`
+	TotalIncome    money.Money             `json:"totalIncome"`
+	WHT            money.Money             `json:"wht"`
+	Allowances     []taxcal.AllowanceClaim `json:"allowances"`
+	TaxYear        int                     `json:"taxYear,omitempty"`
+	Dependants     *taxcal.Dependants      `json:"dependants,omitempty"`
+	Incomes        []taxcal.Income         `json:"incomes,omitempty"`
+	FilingDate     *taxcal.Date            `json:"filingDate,omitempty"`
+	PaymentDate    *taxcal.Date            `json:"paymentDate,omitempty"`
+	Dividends      []taxcal.Dividend       `json:"dividends,omitempty"`
+	DividendMethod string                  `json:"dividendMethod,omitempty"`
+	Severance      *taxcal.Severance       `json:"severance,omitempty"`
 }
 
 // validate taxRequest struct
 func ValidateTaxRequestAmount(req TaxRequest) error {
 
-	// check if TotalIncome value is not number
-	if IsNotNumber(req.TotalIncome) {
-		return fmt.Errorf("totalIncome must be a non-negative value")
+	// check if TotalIncome is a positive value
+	if req.TotalIncome.IsNegative() {
+		return apierror.Invalidf("totalIncome", "totalIncome must be a non-negative value")
 	}
 
-	// check if TotalIncome is a positive value
-	if req.TotalIncome < 0 {
-		return fmt.Errorf("totalIncome must be a non-negative value")
+	// check if WHT is positive value
+	if req.WHT.IsNegative() {
+		return apierror.Invalidf("wht", "wht must be a non-negative value")
 	}
 
-	// check if wht value is not number
-	if IsNotNumber(req.WHT) {
-		return fmt.Errorf("wht must be a non-negative value")
+	// check taxYear ถ้าระบุมา ต้องมีตารางขั้นบันใดภาษีของปีนั้น
+	if req.TaxYear != 0 {
+		if _, err := taxcal.TaxBracketsFor(req.TaxYear); err != nil {
+			return apierror.Invalidf("taxYear", "%v", err)
+		}
 	}
 
-	// check if WHT is positive value
-	if req.WHT < 0 {
-		return fmt.Errorf("wht must be a non-negative value")
+	// check เงินได้แยกประเภท แปลงสกุลต่างประเทศเป็นบาท แล้วรวมกับ totalIncome เป็นเงินได้ทั้งหมด
+	if err := ValidateIncomes(req.Incomes); err != nil {
+		return err
+	}
+	incomes, err := taxcal.ConvertIncomes(req.Incomes, req.TaxYear)
+	if err != nil {
+		return err
+	}
+	grossIncome := req.TotalIncome
+	for _, income := range incomes {
+		grossIncome = grossIncome.Add(income.Amount)
+	}
+
+	// check เงินปันผล ซึ่งนับเป็นเงินได้ทั้งหมดด้วย
+	if err := ValidateDividends(req.Dividends, req.DividendMethod); err != nil {
+		return err
+	}
+	for _, dividend := range req.Dividends {
+		grossIncome = grossIncome.Add(dividend.Amount)
+	}
+
+	// check เงินได้ก้อนเดียวที่ออกจากงาน ซึ่ง wht รวมภาษีที่ถูกหักจากเงินก้อนนี้ได้
+	if err := ValidateSeverance(req.Severance); err != nil {
+		return err
+	}
+	if req.Severance != nil {
+		grossIncome = grossIncome.Add(req.Severance.Amount)
 	}
 
-	if req.WHT > req.TotalIncome {
-		return fmt.Errorf("please ensure that Withholding Tax(WHT) not exceed your total income. Let us know if you need any help")
+	if req.WHT.GreaterThan(grossIncome) {
+		return apierror.Invalidf("wht", "please ensure that Withholding Tax(WHT) not exceed your total income. Let us know if you need any help")
 	}
 
 	// check if allowances array is not empty
 	if len(req.Allowances) == 0 {
-		return fmt.Errorf("at least one allowance must be provided")
+		return apierror.Invalidf("allowances", "at least one allowance must be provided")
 	}
 
 	// check each allowance
-	for _, allowance := range req.Allowances {
-		// Check if AllowanceType is not empty
-		if allowance.AllowanceType == "" ||
-			(allowance.AllowanceType != "donation" &&
-				allowance.AllowanceType != "k-receipt" &&
-				allowance.AllowanceType != "personalDeduction") {
-			return fmt.Errorf("please ensure that allowanceType inputed correctly")
+	for i, allowance := range req.Allowances {
+		// Check if AllowanceType ลงทะเบียนไว้ใน allowance registry
+		if _, ok := taxcal.LookupAllowance(allowance.AllowanceType); !ok {
+			return apierror.Invalidf(fmt.Sprintf("allowances[%d].allowanceType", i), "please ensure that allowanceType inputed correctly")
 		}
 		// check if Amount is a positive value
-		if allowance.Amount < 0 {
-			return fmt.Errorf("amount for %s must be a non-negative value", allowance.AllowanceType)
+		if allowance.Amount.IsNegative() {
+			return apierror.Invalidf(fmt.Sprintf("allowances[%d].amount", i), "amount for %s must be a non-negative value", allowance.AllowanceType)
 		}
 	}
 
+	// check ข้อมูลครอบครัวสำหรับค่าลดหย่อนครอบครัว
+	if err := ValidateDependants(req.Dependants, req.TaxYear); err != nil {
+		return err
+	}
+
+	// check วันที่ยื่นแบบและวันที่ชำระ สำหรับเงินเพิ่มและค่าปรับ
+	if err := ValidateFilingDates(req.TaxYear, req.FilingDate, req.PaymentDate); err != nil {
+		return err
+	}
+
 	// no validation errors  return nil
 	return nil
 }
@@ -2,6 +2,9 @@ package validityguard
 
 import (
 	"testing"
+
+	"github.com/windeesel365/assessment-tax/money"
+	"github.com/windeesel365/assessment-tax/taxcal"
 )
 
 func TestValidateTaxRequestAmount(t *testing.T) {
@@ -13,13 +16,10 @@ func TestValidateTaxRequestAmount(t *testing.T) {
 		{
 			name: "Valid input",
 			req: TaxRequest{
-				TotalIncome: 50000,
-				WHT:         10000,
-				Allowances: []struct {
-					AllowanceType string  `json:"allowanceType"`
-					Amount        float64 `json:"amount"`
-				}{
-					{AllowanceType: "donation", Amount: 300},
+				TotalIncome: money.New(50000),
+				WHT:         money.New(10000),
+				Allowances: []taxcal.AllowanceClaim{
+					{AllowanceType: "donation", Amount: money.New(300)},
 				},
 			},
 			wantErr: false,
@@ -27,13 +27,10 @@ func TestValidateTaxRequestAmount(t *testing.T) {
 		{
 			name: "Negative total income",
 			req: TaxRequest{
-				TotalIncome: -50000,
-				WHT:         10000,
-				Allowances: []struct {
-					AllowanceType string  `json:"allowanceType"`
-					Amount        float64 `json:"amount"`
-				}{
-					{AllowanceType: "donation", Amount: 300},
+				TotalIncome: money.New(-50000),
+				WHT:         money.New(10000),
+				Allowances: []taxcal.AllowanceClaim{
+					{AllowanceType: "donation", Amount: money.New(300)},
 				},
 			},
 			wantErr: true,
@@ -41,13 +38,10 @@ func TestValidateTaxRequestAmount(t *testing.T) {
 		{
 			name: "Zero total income",
 			req: TaxRequest{
-				TotalIncome: 0,
-				WHT:         10000,
-				Allowances: []struct {
-					AllowanceType string  `json:"allowanceType"`
-					Amount        float64 `json:"amount"`
-				}{
-					{AllowanceType: "donation", Amount: 300},
+				TotalIncome: money.New(0),
+				WHT:         money.New(10000),
+				Allowances: []taxcal.AllowanceClaim{
+					{AllowanceType: "donation", Amount: money.New(300)},
 				},
 			},
 			wantErr: true,
@@ -55,13 +49,10 @@ func TestValidateTaxRequestAmount(t *testing.T) {
 		{
 			name: "Negative WHT",
 			req: TaxRequest{
-				TotalIncome: 50000,
-				WHT:         -10000,
-				Allowances: []struct {
-					AllowanceType string  `json:"allowanceType"`
-					Amount        float64 `json:"amount"`
-				}{
-					{AllowanceType: "donation", Amount: 300},
+				TotalIncome: money.New(50000),
+				WHT:         money.New(-10000),
+				Allowances: []taxcal.AllowanceClaim{
+					{AllowanceType: "donation", Amount: money.New(300)},
 				},
 			},
 			wantErr: true,
@@ -69,18 +60,61 @@ func TestValidateTaxRequestAmount(t *testing.T) {
 		{
 			name: "Multiple allowances",
 			req: TaxRequest{
-				TotalIncome: 75000,
-				WHT:         15000,
-				Allowances: []struct {
-					AllowanceType string  `json:"allowanceType"`
-					Amount        float64 `json:"amount"`
-				}{
-					{AllowanceType: "donation", Amount: 500},
-					{AllowanceType: "k-receipt", Amount: 1000},
+				TotalIncome: money.New(75000),
+				WHT:         money.New(15000),
+				Allowances: []taxcal.AllowanceClaim{
+					{AllowanceType: "donation", Amount: money.New(500)},
+					{AllowanceType: "k-receipt", Amount: money.New(1000)},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Personal allowance",
+			req: TaxRequest{
+				TotalIncome: money.New(500000),
+				WHT:         money.New(0),
+				Allowances: []taxcal.AllowanceClaim{
+					{AllowanceType: "personal", Amount: money.New(60000)},
 				},
 			},
 			wantErr: false,
 		},
+		{
+			name: "Unregistered allowance type",
+			req: TaxRequest{
+				TotalIncome: money.New(500000),
+				WHT:         money.New(0),
+				Allowances: []taxcal.AllowanceClaim{
+					{AllowanceType: "personalDeduction", Amount: money.New(60000)},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Supported tax year",
+			req: TaxRequest{
+				TotalIncome: money.New(50000),
+				WHT:         money.New(0),
+				Allowances: []taxcal.AllowanceClaim{
+					{AllowanceType: "donation", Amount: money.New(300)},
+				},
+				TaxYear: 2566,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Unsupported tax year",
+			req: TaxRequest{
+				TotalIncome: money.New(50000),
+				WHT:         money.New(0),
+				Allowances: []taxcal.AllowanceClaim{
+					{AllowanceType: "donation", Amount: money.New(300)},
+				},
+				TaxYear: 2500,
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,28 @@
+package validityguard
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/windeesel365/assessment-tax/apierror"
+)
+
+// DefaultBatchLimit จำนวนรายการสูงสุดต่อ batch เมื่อ server ไม่ได้กำหนดเอง
+const DefaultBatchLimit = 1000
+
+// BatchRequest คือ body ของ tax/calculations/batch: array ของ body แบบเดียวกับ tax/calculations
+// เก็บเป็น raw JSON เพื่อ validate แต่ละรายการแยกกัน รายการที่ผิดไม่ทำให้ทั้ง batch ล้ม
+type BatchRequest []json.RawMessage
+
+// validate BatchRequest, body ของแต่ละรายการ validate ต่อใน handletax
+// batch ที่เกิน limit ตอบ 413 เพื่อให้ client แบ่ง batch ใหม่
+func ValidateBatchRequest(req BatchRequest, limit int) error {
+	if len(req) == 0 {
+		return apierror.Invalidf("", "batch must contain at least one tax request")
+	}
+	if len(req) > limit {
+		return apierror.Newf(http.StatusRequestEntityTooLarge, apierror.CodeTooLarge,
+			"batch must contain at most %d tax requests, got %d", limit, len(req))
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package validityguard
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/windeesel365/assessment-tax/apierror"
+)
+
+func TestValidateBatchRequest(t *testing.T) {
+	item := json.RawMessage(`{"totalIncome":500000.0,"wht":0.0,"allowances":[{"allowanceType":"donation","amount":0.0}]}`)
+
+	tests := []struct {
+		name       string
+		req        BatchRequest
+		limit      int
+		wantStatus int
+	}{
+		{"Within limit", BatchRequest{item, item}, 2, 0},
+		{"Empty batch", BatchRequest{}, 2, http.StatusBadRequest},
+		{"Over limit", BatchRequest{item, item, item}, 2, http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateBatchRequest(tt.req, tt.limit)
+			if tt.wantStatus == 0 {
+				if err != nil {
+					t.Errorf("ValidateBatchRequest() unexpected error = %v", err)
+				}
+				return
+			}
+			apiErr, ok := err.(*apierror.Error)
+			if !ok || apiErr.Status != tt.wantStatus {
+				t.Errorf("ValidateBatchRequest() error = %v, want status %d", err, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package validityguard
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/windeesel365/assessment-tax/apierror"
+)
+
+// MaxCompareScenarios จำนวน scenario สูงสุดที่เทียบได้ในครั้งเดียว
+const MaxCompareScenarios = 10
+
+// CompareScenario คือ scenario หนึ่งรายการ, Request เป็น body แบบเดียวกับ tax/calculations
+// เก็บเป็น raw JSON เพื่อ validate key order และ key ซ้ำแบบเดียวกับ tax/calculations
+type CompareScenario struct {
+	Name    string          `json:"name"`
+	Request json.RawMessage `json:"request"`
+}
+
+// data structure pattern ที่ user client request สำหรับเทียบหลาย scenario
+// scenario แรกคือ baseline ที่ scenario อื่นนำไปเทียบ
+type CompareRequest struct {
+	Scenarios []CompareScenario `json:"scenarios"`
+}
+
+// validate CompareRequest, body ของแต่ละ scenario validate ต่อใน handletax
+func ValidateCompareRequest(req CompareRequest) error {
+	if len(req.Scenarios) < 2 || len(req.Scenarios) > MaxCompareScenarios {
+		return apierror.Invalidf("scenarios", "scenarios must contain between 2 and %d scenarios", MaxCompareScenarios)
+	}
+
+	names := map[string]bool{}
+	for i, scenario := range req.Scenarios {
+		if scenario.Name == "" {
+			return apierror.Invalidf(fmt.Sprintf("scenarios[%d].name", i), "scenarios[%d].name is required", i)
+		}
+		if names[scenario.Name] {
+			return apierror.Invalidf(fmt.Sprintf("scenarios[%d].name", i), "scenarios[%d].name %q is redundant, please check and fill again", i, scenario.Name)
+		}
+		names[scenario.Name] = true
+		if len(scenario.Request) == 0 {
+			return apierror.Invalidf(fmt.Sprintf("scenarios[%d].request", i), "scenarios[%d].request is required", i)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package validityguard
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestValidateCompareRequest(t *testing.T) {
+	body := json.RawMessage(`{"totalIncome":500000.0,"wht":0.0,"allowances":[{"allowanceType":"donation","amount":0.0}]}`)
+	scenario := func(name string) CompareScenario {
+		return CompareScenario{Name: name, Request: body}
+	}
+	tooMany := make([]CompareScenario, 0, MaxCompareScenarios+1)
+	for i := 0; i <= MaxCompareScenarios; i++ {
+		tooMany = append(tooMany, scenario(fmt.Sprintf("scenario %d", i)))
+	}
+
+	tests := []struct {
+		name    string
+		req     CompareRequest
+		wantErr bool
+	}{
+		{"Two scenarios", CompareRequest{Scenarios: []CompareScenario{scenario("without ssf"), scenario("with ssf")}}, false},
+		{"Single scenario", CompareRequest{Scenarios: []CompareScenario{scenario("without ssf")}}, true},
+		{"Too many scenarios", CompareRequest{Scenarios: tooMany}, true},
+		{"Missing name", CompareRequest{Scenarios: []CompareScenario{scenario("without ssf"), scenario("")}}, true},
+		{"Redundant name", CompareRequest{Scenarios: []CompareScenario{scenario("plan"), scenario("plan")}}, true},
+		{"Missing request", CompareRequest{Scenarios: []CompareScenario{scenario("without ssf"), {Name: "with ssf"}}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCompareRequest(tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCompareRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
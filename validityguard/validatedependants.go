@@ -0,0 +1,46 @@
+package validityguard
+
+import (
+	"fmt"
+
+	"github.com/windeesel365/assessment-tax/apierror"
+	"github.com/windeesel365/assessment-tax/taxcal"
+)
+
+// validate ข้อมูลครอบครัวใน dependants ตามเงื่อนไขค่าลดหย่อน
+func ValidateDependants(dependants *taxcal.Dependants, taxYear int) error {
+	if dependants == nil {
+		return nil
+	}
+	if taxYear == 0 {
+		taxYear = taxcal.DefaultTaxYear
+	}
+
+	for i, child := range dependants.Children {
+		// ปีเกิดต้องเป็น พ.ศ. และไม่เกินปีภาษี
+		if child.BirthYear <= 0 || child.BirthYear > taxYear {
+			return apierror.Invalidf(fmt.Sprintf("dependants.children[%d].birthYear", i), "children[%d].birthYear must be a Buddhist year not later than tax year %d", i, taxYear)
+		}
+	}
+
+	if len(dependants.Parents) > taxcal.MaxParentsForCare {
+		return apierror.Invalidf("dependants.parents", "parental care can be claimed for at most %d parents", taxcal.MaxParentsForCare)
+	}
+	for i, parent := range dependants.Parents {
+		if parent.Age < taxcal.ParentCareMinAge {
+			return apierror.Invalidf(fmt.Sprintf("dependants.parents[%d].age", i), "parents[%d] must be aged %d or over to claim parental care", i, taxcal.ParentCareMinAge)
+		}
+		if parent.Income.IsNegative() {
+			return apierror.Invalidf(fmt.Sprintf("dependants.parents[%d].income", i), "parents[%d].income must be a non-negative value", i)
+		}
+		if parent.Income.GreaterThan(taxcal.ParentCareMaxIncome) {
+			return apierror.Invalidf(fmt.Sprintf("dependants.parents[%d].income", i), "parents[%d] income must not exceed %s THB to claim parental care", i, taxcal.ParentCareMaxIncome.StringFixed(0))
+		}
+	}
+
+	if dependants.DisabledDependants < 0 {
+		return apierror.Invalidf("dependants.disabledDependants", "disabledDependants must be a non-negative value")
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package validityguard
+
+import (
+	"testing"
+
+	"github.com/windeesel365/assessment-tax/money"
+	"github.com/windeesel365/assessment-tax/taxcal"
+)
+
+func TestValidateDependants(t *testing.T) {
+	tests := []struct {
+		name       string
+		dependants *taxcal.Dependants
+		taxYear    int
+		wantErr    bool
+	}{
+		{"No dependants", nil, 0, false},
+		{
+			name: "Valid family",
+			dependants: &taxcal.Dependants{
+				Spouse:             &taxcal.Spouse{HasIncome: false},
+				Children:           []taxcal.Child{{BirthYear: 2560}, {BirthYear: 2563}},
+				Parents:            []taxcal.Parent{{Age: 65, Income: money.Zero}},
+				DisabledDependants: 1,
+			},
+			taxYear: 2567,
+			wantErr: false,
+		},
+		{
+			name:       "Child born after tax year",
+			dependants: &taxcal.Dependants{Children: []taxcal.Child{{BirthYear: 2568}}},
+			taxYear:    2567,
+			wantErr:    true,
+		},
+		{
+			name:       "Child birth year in CE",
+			dependants: &taxcal.Dependants{Children: []taxcal.Child{{BirthYear: 0}}},
+			wantErr:    true,
+		},
+		{
+			name:       "Parent under 60",
+			dependants: &taxcal.Dependants{Parents: []taxcal.Parent{{Age: 59}}},
+			wantErr:    true,
+		},
+		{
+			name:       "Parent income too high",
+			dependants: &taxcal.Dependants{Parents: []taxcal.Parent{{Age: 70, Income: money.NewFromInt(30001)}}},
+			wantErr:    true,
+		},
+		{
+			name: "Too many parents",
+			dependants: &taxcal.Dependants{Parents: []taxcal.Parent{
+				{Age: 60}, {Age: 61}, {Age: 62}, {Age: 63}, {Age: 64},
+			}},
+			wantErr: true,
+		},
+		{
+			name:       "Negative disabled dependants",
+			dependants: &taxcal.Dependants{DisabledDependants: -1},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateDependants(tt.dependants, tt.taxYear)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateDependants() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package validityguard
+
+import (
+	"fmt"
+
+	"github.com/windeesel365/assessment-tax/apierror"
+	"github.com/windeesel365/assessment-tax/taxcal"
+)
+
+// ValidateDividends เช็คเงินปันผลและวิธีเสียภาษีเงินปันผล
+// corporateTaxRate ต้องอยู่ระหว่าง 0-99 เพราะเครดิตภาษีหารด้วย 100 - อัตรา
+func ValidateDividends(dividends []taxcal.Dividend, method string) error {
+	for i, dividend := range dividends {
+		if dividend.Amount.IsNegative() {
+			return apierror.Invalidf(fmt.Sprintf("dividends[%d].amount", i), "dividends[%d].amount must be a non-negative value", i)
+		}
+		if rate := dividend.CorporateTaxRate; rate != nil && (*rate < 0 || *rate > 99) {
+			return apierror.Invalidf(fmt.Sprintf("dividends[%d].corporateTaxRate", i), "dividends[%d].corporateTaxRate must be between 0 and 99", i)
+		}
+	}
+
+	switch method {
+	case "":
+	case taxcal.DividendMethodFinal, taxcal.DividendMethodInclude:
+		if len(dividends) == 0 {
+			return apierror.Invalidf("dividendMethod", "dividendMethod requires dividends")
+		}
+	default:
+		return apierror.Invalidf("dividendMethod", "dividendMethod must be %s or %s", taxcal.DividendMethodFinal, taxcal.DividendMethodInclude)
+	}
+
+	return nil
+}
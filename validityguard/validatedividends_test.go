@@ -0,0 +1,41 @@
+package validityguard
+
+import (
+	"testing"
+
+	"github.com/windeesel365/assessment-tax/money"
+	"github.com/windeesel365/assessment-tax/taxcal"
+)
+
+func TestValidateDividends(t *testing.T) {
+	rate := func(r int) *int { return &r }
+	dividend := func(amount int64, corporateTaxRate *int) []taxcal.Dividend {
+		return []taxcal.Dividend{{Amount: money.NewFromInt(amount), CorporateTaxRate: corporateTaxRate}}
+	}
+
+	tests := []struct {
+		name      string
+		dividends []taxcal.Dividend
+		method    string
+		wantErr   bool
+	}{
+		{"No dividends", nil, "", false},
+		{"Default corporate rate", dividend(100000, nil), "", false},
+		{"No tax credit", dividend(100000, rate(0)), taxcal.DividendMethodInclude, false},
+		{"Final method", dividend(100000, rate(30)), taxcal.DividendMethodFinal, false},
+		{"Negative amount", dividend(-1, nil), "", true},
+		{"Negative corporate rate", dividend(100000, rate(-1)), "", true},
+		{"Corporate rate 100", dividend(100000, rate(100)), "", true},
+		{"Unknown method", dividend(100000, nil), "separate", true},
+		{"Method without dividends", nil, taxcal.DividendMethodFinal, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateDividends(tt.dividends, tt.method)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateDividends() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
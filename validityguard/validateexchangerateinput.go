@@ -0,0 +1,61 @@
+package validityguard
+
+import (
+	"github.com/shopspring/decimal"
+	"github.com/windeesel365/assessment-tax/apierror"
+	"github.com/windeesel365/assessment-tax/fxrates"
+	"github.com/windeesel365/assessment-tax/jsonvalidate"
+	"github.com/windeesel365/assessment-tax/taxcal"
+)
+
+// pattern ที่ admin input request สำหรับอัตราแลกเปลี่ยน
+// date ไม่ระบุคืออัตราเฉลี่ยทั้งปีภาษี
+type ExchangeRateInput struct {
+	Currency string          `json:"currency"`
+	TaxYear  int             `json:"taxYear"`
+	Date     *taxcal.Date    `json:"date,omitempty"`
+	Rate     decimal.Decimal `json:"rate"`
+}
+
+// ToRate แปลง input เป็น fxrates.Rate
+func (in ExchangeRateInput) ToRate() fxrates.Rate {
+	rate := fxrates.Rate{Currency: in.Currency, TaxYear: in.TaxYear, Rate: in.Rate}
+	if in.Date != nil {
+		date := in.Date.Time
+		rate.Date = &date
+	}
+	return rate
+}
+
+// validate input data ของอัตราแลกเปลี่ยน
+func ValidateExchangeRateInput(body []byte) error {
+	//validate raw JSON not empty
+	if len(body) == 0 {
+		return apierror.BadRequest(apierror.CodeEmptyBody, "Please provide input data")
+	}
+
+	//validate struct แบบ strict, ไม่รับ key ที่ไม่รู้จักหรือซ้ำ
+	in := ExchangeRateInput{}
+	if err := jsonvalidate.StrictDecode(body, &in); err != nil {
+		return apierror.Decode(err)
+	}
+
+	if !fxrates.ValidCurrency(in.Currency) || in.Currency == fxrates.BaseCurrency {
+		return apierror.Invalidf("currency", "Please ensure currency is a 3-letter ISO 4217 code other than THB, such as USD.")
+	}
+
+	if _, err := taxcal.TaxBracketsFor(in.TaxYear); err != nil || in.TaxYear == 0 {
+		return apierror.Invalidf("taxYear", "Please ensure taxYear is a supported tax year.")
+	}
+
+	// อัตรารายวันต้องเป็นวันในปีภาษีนั้น
+	if in.Date != nil && in.Date.Year() != taxcal.GregorianYear(in.TaxYear) {
+		return apierror.Invalidf("date", "Please ensure date is within the tax year.")
+	}
+
+	if !in.Rate.IsPositive() {
+		return apierror.Invalidf("rate", "Please ensure rate is more than 0.")
+	}
+
+	return nil
+}
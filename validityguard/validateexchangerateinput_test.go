@@ -0,0 +1,31 @@
+package validityguard
+
+import "testing"
+
+func TestValidateExchangeRateInput(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr bool
+	}{
+		{"Year average", `{"currency": "USD", "taxYear": 2567, "rate": 35.5}`, false},
+		{"Daily rate", `{"currency": "EUR", "taxYear": 2567, "date": "2024-06-14", "rate": 38.25}`, false},
+		{"Empty body", ``, true},
+		{"Unknown key", `{"currency": "USD", "taxYear": 2567, "rate": 35.5, "bank": "BOT"}`, true},
+		{"Lowercase currency", `{"currency": "usd", "taxYear": 2567, "rate": 35.5}`, true},
+		{"Baht", `{"currency": "THB", "taxYear": 2567, "rate": 1}`, true},
+		{"Missing tax year", `{"currency": "USD", "rate": 35.5}`, true},
+		{"Unsupported tax year", `{"currency": "USD", "taxYear": 2500, "rate": 35.5}`, true},
+		{"Date outside tax year", `{"currency": "USD", "taxYear": 2567, "date": "2025-01-01", "rate": 35.5}`, true},
+		{"Zero rate", `{"currency": "USD", "taxYear": 2567, "rate": 0}`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateExchangeRateInput([]byte(tt.body))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateExchangeRateInput() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package validityguard
+
+import (
+	"github.com/windeesel365/assessment-tax/apierror"
+	"github.com/windeesel365/assessment-tax/taxcal"
+)
+
+// ValidateFilingDates เช็ควันที่ยื่นแบบและวันที่ชำระภาษี
+// ยื่นแบบได้หลังสิ้นปีภาษีเท่านั้น และวันที่ชำระต้องไม่ก่อนวันที่ยื่นแบบ
+func ValidateFilingDates(taxYear int, filingDate, paymentDate *taxcal.Date) error {
+	if filingDate == nil {
+		if paymentDate != nil {
+			return apierror.Invalidf("paymentDate", "paymentDate requires filingDate")
+		}
+		return nil
+	}
+
+	// ปีภาษีสิ้นสุด 31 ธันวาคม คือ 3 เดือนก่อนวันครบกำหนดยื่นแบบ
+	deadline := taxcal.FilingDeadline(taxYear)
+	if filingDate.Year() < deadline.Year() {
+		return apierror.Invalidf("filingDate", "filingDate must be after the end of tax year, on or after %d-01-01", deadline.Year())
+	}
+
+	if paymentDate != nil && paymentDate.Before(filingDate.Time) {
+		return apierror.Invalidf("paymentDate", "paymentDate must not be before filingDate")
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package validityguard
+
+import (
+	"testing"
+	"time"
+
+	"github.com/windeesel365/assessment-tax/taxcal"
+)
+
+func TestValidateFilingDates(t *testing.T) {
+	date := func(year int, month time.Month, day int) *taxcal.Date {
+		d := taxcal.NewDate(year, month, day)
+		return &d
+	}
+
+	tests := []struct {
+		name        string
+		taxYear     int
+		filingDate  *taxcal.Date
+		paymentDate *taxcal.Date
+		wantErr     bool
+	}{
+		{"No dates", 0, nil, nil, false},
+		{"Filing date only", 2567, date(2025, time.April, 10), nil, false},
+		{"Paid after filing", 2567, date(2025, time.April, 10), date(2025, time.May, 1), false},
+		{"Default tax year", 0, date(2025, time.February, 1), nil, false},
+		{"Payment without filing", 2567, nil, date(2025, time.April, 10), true},
+		{"Filed before tax year ends", 2567, date(2024, time.December, 31), nil, true},
+		{"Paid before filing", 2567, date(2025, time.April, 10), date(2025, time.April, 9), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateFilingDates(tt.taxYear, tt.filingDate, tt.paymentDate)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateFilingDates() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package validityguard
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/windeesel365/assessment-tax/apierror"
+	"github.com/windeesel365/assessment-tax/fxrates"
+	"github.com/windeesel365/assessment-tax/taxcal"
+)
+
+// validate เงินได้แยกประเภทตามมาตรา 40
+func ValidateIncomes(incomes []taxcal.Income) error {
+	for i, income := range incomes {
+		path := fmt.Sprintf("incomes[%d]", i)
+		section, ok := taxcal.LookupIncomeSection(income.Section)
+		if !ok {
+			return apierror.Invalidf(path+".section", "%s.section must be one of: %s", path, strings.Join(taxcal.IncomeSectionNames(), ", "))
+		}
+		if !section.HasSubType(income.SubType) {
+			return apierror.Invalidf(path+".subType", "%s.subType %q is not valid for income section %s", path, income.SubType, income.Section)
+		}
+		if income.Amount.IsNegative() {
+			return apierror.Invalidf(path+".amount", "%s.amount must be a non-negative value", path)
+		}
+		if income.Currency != "" && !fxrates.ValidCurrency(income.Currency) {
+			return apierror.Invalidf(path+".currency", "%s.currency must be a 3-letter ISO 4217 code such as USD", path)
+		}
+		if income.Date != nil && income.Currency == "" {
+			return apierror.Invalidf(path+".date", "%s.date requires currency", path)
+		}
+
+		switch income.ExpenseMethod {
+		case "", taxcal.ExpenseMethodFlat:
+			if !income.ActualExpenses.IsZero() {
+				return apierror.Invalidf(path+".actualExpenses", "%s.actualExpenses requires expenseMethod %s", path, taxcal.ExpenseMethodActual)
+			}
+		case taxcal.ExpenseMethodActual:
+			if !section.AllowsActual {
+				return apierror.Invalidf(path+".expenseMethod", "%s income section %s does not allow actual expenses", path, income.Section)
+			}
+			if income.ActualExpenses.IsNegative() {
+				return apierror.Invalidf(path+".actualExpenses", "%s.actualExpenses must be a non-negative value", path)
+			}
+			if income.ActualExpenses.GreaterThan(income.Amount) {
+				return apierror.Invalidf(path+".actualExpenses", "%s.actualExpenses must not exceed amount", path)
+			}
+		default:
+			return apierror.Invalidf(path+".expenseMethod", "%s.expenseMethod must be %s or %s", path, taxcal.ExpenseMethodFlat, taxcal.ExpenseMethodActual)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package validityguard
+
+import (
+	"testing"
+
+	"github.com/windeesel365/assessment-tax/money"
+	"github.com/windeesel365/assessment-tax/taxcal"
+)
+
+func TestValidateIncomes(t *testing.T) {
+	tests := []struct {
+		name    string
+		incomes []taxcal.Income
+		wantErr bool
+	}{
+		{"No incomes", nil, false},
+		{
+			name: "Valid incomes",
+			incomes: []taxcal.Income{
+				{Section: "40(1)", Amount: money.NewFromInt(600000)},
+				{Section: "40(5)", SubType: "vehicle", Amount: money.NewFromInt(50000)},
+				{Section: "40(8)", Amount: money.NewFromInt(200000), ExpenseMethod: "actual", ActualExpenses: money.NewFromInt(150000)},
+			},
+			wantErr: false,
+		},
+		{"Unknown section", []taxcal.Income{{Section: "salary", Amount: money.NewFromInt(1)}}, true},
+		{"Unknown subType", []taxcal.Income{{Section: "40(5)", SubType: "boat", Amount: money.NewFromInt(1)}}, true},
+		{"Negative amount", []taxcal.Income{{Section: "40(1)", Amount: money.NewFromInt(-1)}}, true},
+		{"Foreign currency", []taxcal.Income{{Section: "40(2)", Amount: money.NewFromInt(1), Currency: "USD"}}, false},
+		{"Invalid currency", []taxcal.Income{{Section: "40(2)", Amount: money.NewFromInt(1), Currency: "usd"}}, true},
+		{"Date without currency", []taxcal.Income{{Section: "40(2)", Amount: money.NewFromInt(1), Date: &taxcal.Date{}}}, true},
+		{"Unknown expense method", []taxcal.Income{{Section: "40(8)", Amount: money.NewFromInt(1), ExpenseMethod: "guess"}}, true},
+		{"Actual expenses for salary", []taxcal.Income{{Section: "40(1)", Amount: money.NewFromInt(1), ExpenseMethod: "actual"}}, true},
+		{"Actual expenses without method", []taxcal.Income{{Section: "40(8)", Amount: money.NewFromInt(10), ActualExpenses: money.NewFromInt(5)}}, true},
+		{
+			name:    "Actual expenses above amount",
+			incomes: []taxcal.Income{{Section: "40(8)", Amount: money.NewFromInt(10), ExpenseMethod: "actual", ActualExpenses: money.NewFromInt(11)}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateIncomes(tt.incomes)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateIncomes() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
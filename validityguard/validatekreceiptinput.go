@@ -1,65 +1,54 @@
 package validityguard
 
 import (
-	"encoding/json"
-	"net/http"
-	"strings"
-
-	"github.com/labstack/echo/v4"
+	"github.com/windeesel365/assessment-tax/apierror"
 	"github.com/windeesel365/assessment-tax/jsonvalidate"
+	"github.com/windeesel365/assessment-tax/money"
 )
 
 // pattern ที่ admin input request
 type Deduction struct {
-	Amount float64 `json:"amount"`
+	Amount money.Money `json:"amount"`
 }
 
 // validation input data ของ setKReceipt
 func ValidateInputsetKReceipt(body []byte) error {
 	//validate raw JSON not empty
 	if len(body) == 0 {
-		return echo.NewHTTPError(http.StatusBadRequest, "Please provide input data")
-	}
-
-	//แปลง byte array เป็น string
-	jsonString := string(body)
-
-	//check if strings.Count "amount" อยู่ใน string มากกว่า 1 ครั้ง
-	if strings.Count(jsonString, "amount") > 1 {
-		return echo.NewHTTPError(http.StatusBadRequest, "Input data 'amount' more than once, check and fill again")
+		return apierror.BadRequest(apierror.CodeEmptyBody, "Please provide input data")
 	}
 
 	//validate raw JSON root-level key count match กับ key count of correct pattern
 	expectedKeys := []string{"amount"}
 	count, err := jsonvalidate.JsonRootLevelKeyCount(string(body))
 	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "Invalid input")
+		return apierror.BadRequest(apierror.CodeInvalidJSON, "Invalid input")
 	}
 	if count != len(expectedKeys) {
-		return echo.NewHTTPError(http.StatusBadRequest, "Invalid input. Please ensure you enter only one amount, corresponding to setting upper limit of k-receipt.")
+		return apierror.BadRequest(apierror.CodeInvalidKeys, "Invalid input. Please ensure you enter only one amount, corresponding to setting upper limit of k-receipt.")
 	}
 
 	//validate raw JSON root-level key count order
 	if err := jsonvalidate.CheckJSONOrder(body, expectedKeys); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		return apierror.BadRequestf(apierror.CodeInvalidKeys, "%v", err)
 	}
 
 	//validate struct and amount
 	d := new(Deduction)
-	if err := json.Unmarshal(body, d); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "Invalid input format: "+err.Error())
+	if err := jsonvalidate.StrictDecode(body, d); err != nil {
+		return apierror.Decode(err)
 	}
 
 	if err := validateFields(body, d); err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, "Invalid input format. Please check the input format again")
+		return apierror.BadRequest(apierror.CodeInvalidKeys, "Invalid input format. Please check the input format again")
 	}
 
-	if d.Amount > 100000.0 {
-		return echo.NewHTTPError(http.StatusBadRequest, "Please ensure kReceipt UpperLimit does not exceed THB 100,000.")
+	if d.Amount.GreaterThan(money.NewFromInt(100000)) {
+		return apierror.Invalidf("amount", "Please ensure kReceipt UpperLimit does not exceed THB 100,000.")
 	}
 
-	if d.Amount <= 0.0 {
-		return echo.NewHTTPError(http.StatusBadRequest, "Please ensure kReceipt UpperLimit must be more than THB 0.")
+	if !d.Amount.IsPositive() {
+		return apierror.Invalidf("amount", "Please ensure kReceipt UpperLimit must be more than THB 0.")
 	}
 
 	return nil
@@ -0,0 +1,62 @@
+package validityguard
+
+import (
+	"fmt"
+
+	"github.com/windeesel365/assessment-tax/apierror"
+	"github.com/windeesel365/assessment-tax/taxcal"
+)
+
+// data structure pattern ที่ user client request สำหรับคำนวนภาษีหัก ณ ที่จ่ายรายเดือน
+type PayrollRequest struct {
+	Salaries   []taxcal.SalaryPeriod   `json:"salaries"`
+	Bonuses    []taxcal.Bonus          `json:"bonuses,omitempty"`
+	Allowances []taxcal.AllowanceClaim `json:"allowances,omitempty"`
+	Dependants *taxcal.Dependants      `json:"dependants,omitempty"`
+	TaxYear    int                     `json:"taxYear,omitempty"`
+}
+
+// validate PayrollRequest
+func ValidatePayrollRequest(req PayrollRequest) error {
+	if len(req.Salaries) == 0 {
+		return apierror.Invalidf("salaries", "salaries must contain at least one salary period")
+	}
+
+	// fromMonth ต้องอยู่ใน 1-12 และเรียงจากน้อยไปมากไม่ซ้ำกัน
+	lastMonth := 0
+	for i, period := range req.Salaries {
+		if period.FromMonth < 1 || period.FromMonth > taxcal.MonthsPerYear {
+			return apierror.Invalidf(fmt.Sprintf("salaries[%d].fromMonth", i), "fromMonth must be between 1 and %d", taxcal.MonthsPerYear)
+		}
+		if period.FromMonth <= lastMonth {
+			return apierror.Invalidf(fmt.Sprintf("salaries[%d].fromMonth", i), "salaries must be ordered by fromMonth without duplicates")
+		}
+		if period.Amount.IsNegative() {
+			return apierror.Invalidf(fmt.Sprintf("salaries[%d].amount", i), "salary amount must be a non-negative value")
+		}
+		lastMonth = period.FromMonth
+	}
+
+	for i, bonus := range req.Bonuses {
+		if bonus.Month < 1 || bonus.Month > taxcal.MonthsPerYear {
+			return apierror.Invalidf(fmt.Sprintf("bonuses[%d].month", i), "bonus month must be between 1 and %d", taxcal.MonthsPerYear)
+		}
+		if !bonus.Amount.IsPositive() {
+			return apierror.Invalidf(fmt.Sprintf("bonuses[%d].amount", i), "bonus amount must be greater than 0")
+		}
+	}
+
+	if req.TaxYear != 0 {
+		if _, err := taxcal.TaxBracketsFor(req.TaxYear); err != nil {
+			return apierror.Invalidf("taxYear", "%v", err)
+		}
+	}
+
+	for i, allowance := range req.Allowances {
+		if _, ok := taxcal.LookupAllowance(allowance.AllowanceType); !ok {
+			return apierror.Invalidf(fmt.Sprintf("allowances[%d].allowanceType", i), "please ensure that allowanceType inputed correctly")
+		}
+	}
+
+	return ValidateDependants(req.Dependants, req.TaxYear)
+}
@@ -0,0 +1,61 @@
+package validityguard
+
+import (
+	"testing"
+
+	"github.com/windeesel365/assessment-tax/money"
+	"github.com/windeesel365/assessment-tax/taxcal"
+)
+
+func TestValidatePayrollRequest(t *testing.T) {
+	salary := func(fromMonth int, amount int64) taxcal.SalaryPeriod {
+		return taxcal.SalaryPeriod{FromMonth: fromMonth, Amount: money.NewFromInt(amount)}
+	}
+
+	tests := []struct {
+		name    string
+		req     PayrollRequest
+		wantErr bool
+	}{
+		{"Fixed salary", PayrollRequest{Salaries: []taxcal.SalaryPeriod{salary(1, 50000)}}, false},
+		{"Salary change", PayrollRequest{Salaries: []taxcal.SalaryPeriod{salary(1, 50000), salary(7, 60000)}}, false},
+		{"No salaries", PayrollRequest{}, true},
+		{"Month out of range", PayrollRequest{Salaries: []taxcal.SalaryPeriod{salary(13, 50000)}}, true},
+		{"Months out of order", PayrollRequest{Salaries: []taxcal.SalaryPeriod{salary(7, 60000), salary(1, 50000)}}, true},
+		{"Negative salary", PayrollRequest{Salaries: []taxcal.SalaryPeriod{salary(1, -1)}}, true},
+		{
+			name: "Valid bonus",
+			req: PayrollRequest{
+				Salaries: []taxcal.SalaryPeriod{salary(1, 50000)},
+				Bonuses:  []taxcal.Bonus{{Month: 12, Amount: money.NewFromInt(100000)}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Zero bonus",
+			req: PayrollRequest{
+				Salaries: []taxcal.SalaryPeriod{salary(1, 50000)},
+				Bonuses:  []taxcal.Bonus{{Month: 12, Amount: money.Zero}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Unknown allowance",
+			req: PayrollRequest{
+				Salaries:   []taxcal.SalaryPeriod{salary(1, 50000)},
+				Allowances: []taxcal.AllowanceClaim{{AllowanceType: "lottery", Amount: money.NewFromInt(1)}},
+			},
+			wantErr: true,
+		},
+		{"Unsupported tax year", PayrollRequest{Salaries: []taxcal.SalaryPeriod{salary(1, 50000)}, TaxYear: 2500}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePayrollRequest(tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidatePayrollRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
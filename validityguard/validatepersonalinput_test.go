@@ -3,8 +3,8 @@ package validityguard
 import (
 	"testing"
 
-	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/windeesel365/assessment-tax/apierror"
 )
 
 func TestValidatePersonalInput(t *testing.T) {
@@ -13,24 +13,28 @@ func TestValidatePersonalInput(t *testing.T) {
 		body    []byte
 		wantErr bool
 		errMsg  string
+		code    apierror.Code
 	}{
 		{
 			name:    "empty body",
 			body:    []byte(""),
 			wantErr: true,
 			errMsg:  "Please provide input data",
+			code:    apierror.CodeEmptyBody,
 		},
 		{
 			name:    "invalid JSON",
 			body:    []byte("{amount: 50000}"),
 			wantErr: true,
 			errMsg:  "Invalid input",
+			code:    apierror.CodeInvalidJSON,
 		},
 		{
 			name:    "invalid key count",
 			body:    []byte(`{"amount": 50000, "extra": 100}`),
 			wantErr: true,
 			errMsg:  "Invalid input. Please ensure you enter only one amount, corresponding to setting value of personal deduction.",
+			code:    apierror.CodeInvalidKeys,
 		},
 		{
 			name:    "correct input",
@@ -42,12 +46,14 @@ func TestValidatePersonalInput(t *testing.T) {
 			body:    []byte(`{"amount": 100001}`),
 			wantErr: true,
 			errMsg:  "Please ensure Personal Deduction amount does not exceed THB 100,000.",
+			code:    apierror.CodeInvalidValue,
 		},
 		{
 			name:    "amount too low",
 			body:    []byte(`{"amount": 10000}`),
 			wantErr: true,
 			errMsg:  "Please ensure Personal Deduction must be more than THB 10000.",
+			code:    apierror.CodeInvalidValue,
 		},
 	}
 
@@ -56,9 +62,10 @@ func TestValidatePersonalInput(t *testing.T) {
 			err := ValidatePersonalInput(tt.body)
 			if tt.wantErr {
 				assert.Error(t, err)
-				httpErr, ok := err.(*echo.HTTPError)
+				apiErr, ok := err.(*apierror.Error)
 				assert.True(t, ok)
-				assert.Contains(t, httpErr.Message, tt.errMsg)
+				assert.Contains(t, apiErr.Detail.String(), tt.errMsg)
+				assert.Equal(t, tt.code, apiErr.Code)
 			} else {
 				assert.NoError(t, err)
 			}
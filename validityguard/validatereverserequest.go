@@ -0,0 +1,52 @@
+package validityguard
+
+import (
+	"fmt"
+
+	"github.com/windeesel365/assessment-tax/apierror"
+	"github.com/windeesel365/assessment-tax/money"
+	"github.com/windeesel365/assessment-tax/taxcal"
+)
+
+// data structure pattern ที่ user client request สำหรับคำนวนย้อนกลับ
+type ReverseRequest struct {
+	Target     string                  `json:"target"`
+	Amount     money.Money             `json:"amount"`
+	Section    string                  `json:"section,omitempty"`
+	Allowances []taxcal.AllowanceClaim `json:"allowances,omitempty"`
+	Dependants *taxcal.Dependants      `json:"dependants,omitempty"`
+	TaxYear    int                     `json:"taxYear,omitempty"`
+}
+
+// validate ReverseRequest
+func ValidateReverseRequest(req ReverseRequest) error {
+	if req.Target != taxcal.ReverseTargetNet && req.Target != taxcal.ReverseTargetTax {
+		return apierror.Invalidf("target", "target must be %s or %s", taxcal.ReverseTargetNet, taxcal.ReverseTargetTax)
+	}
+
+	if req.Amount.IsNegative() {
+		return apierror.Invalidf("amount", "amount must be a non-negative value")
+	}
+
+	// section ต้องหักค่าใช้จ่ายแบบเหมาได้โดยไม่ต้องระบุ subType
+	if req.Section != "" {
+		section, ok := taxcal.LookupIncomeSection(req.Section)
+		if !ok || !section.HasSubType("") {
+			return apierror.Invalidf("section", "section %q is not a valid income section", req.Section)
+		}
+	}
+
+	if req.TaxYear != 0 {
+		if _, err := taxcal.TaxBracketsFor(req.TaxYear); err != nil {
+			return apierror.Invalidf("taxYear", "%v", err)
+		}
+	}
+
+	for i, allowance := range req.Allowances {
+		if _, ok := taxcal.LookupAllowance(allowance.AllowanceType); !ok {
+			return apierror.Invalidf(fmt.Sprintf("allowances[%d].allowanceType", i), "please ensure that allowanceType inputed correctly")
+		}
+	}
+
+	return ValidateDependants(req.Dependants, req.TaxYear)
+}
@@ -0,0 +1,39 @@
+package validityguard
+
+import (
+	"testing"
+
+	"github.com/windeesel365/assessment-tax/money"
+	"github.com/windeesel365/assessment-tax/taxcal"
+)
+
+func TestValidateReverseRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     ReverseRequest
+		wantErr bool
+	}{
+		{"Net target", ReverseRequest{Target: "net", Amount: money.NewFromInt(400000)}, false},
+		{"Tax target with salary", ReverseRequest{Target: "tax", Amount: money.NewFromInt(20000), Section: "40(1)"}, false},
+		{"Unknown target", ReverseRequest{Target: "gross", Amount: money.NewFromInt(1)}, true},
+		{"Negative amount", ReverseRequest{Target: "net", Amount: money.NewFromInt(-1)}, true},
+		{"Unknown section", ReverseRequest{Target: "net", Amount: money.NewFromInt(1), Section: "40(9)"}, true},
+		{"Unsupported tax year", ReverseRequest{Target: "net", Amount: money.NewFromInt(1), TaxYear: 2500}, true},
+		{
+			name: "Unknown allowance",
+			req: ReverseRequest{Target: "net", Amount: money.NewFromInt(1), Allowances: []taxcal.AllowanceClaim{
+				{AllowanceType: "lottery", Amount: money.NewFromInt(1)},
+			}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateReverseRequest(tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateReverseRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package validityguard
+
+import (
+	"github.com/windeesel365/assessment-tax/apierror"
+	"github.com/windeesel365/assessment-tax/money"
+	"github.com/windeesel365/assessment-tax/taxcal"
+)
+
+// data structure pattern ที่ user client request สำหรับคำนวนภาษีเงินได้ก้อนเดียวที่ออกจากงาน
+type SeveranceRequest struct {
+	Amount         money.Money `json:"amount"`
+	YearsOfService int         `json:"yearsOfService"`
+	TaxYear        int         `json:"taxYear,omitempty"`
+}
+
+// ValidateSeverance เช็คเงินได้ก้อนเดียว, แยกคำนวนภาษีได้เมื่อทำงานครบ taxcal.SeveranceMinYears ปี
+func ValidateSeverance(severance *taxcal.Severance) error {
+	if severance == nil {
+		return nil
+	}
+	if !severance.Amount.IsPositive() {
+		return apierror.Invalidf("severance.amount", "severance.amount must be greater than 0")
+	}
+	if severance.YearsOfService < taxcal.SeveranceMinYears {
+		return apierror.Invalidf("severance.yearsOfService", "severance.yearsOfService must be at least %d years to be taxed separately", taxcal.SeveranceMinYears)
+	}
+	return nil
+}
+
+// validate SeveranceRequest
+func ValidateSeveranceRequest(req SeveranceRequest) error {
+	if req.TaxYear != 0 {
+		if _, err := taxcal.TaxBracketsFor(req.TaxYear); err != nil {
+			return apierror.Invalidf("taxYear", "%v", err)
+		}
+	}
+	return ValidateSeverance(&taxcal.Severance{Amount: req.Amount, YearsOfService: req.YearsOfService})
+}
@@ -0,0 +1,53 @@
+package validityguard
+
+import (
+	"testing"
+
+	"github.com/windeesel365/assessment-tax/money"
+	"github.com/windeesel365/assessment-tax/taxcal"
+)
+
+func TestValidateSeverance(t *testing.T) {
+	tests := []struct {
+		name      string
+		severance *taxcal.Severance
+		wantErr   bool
+	}{
+		{"No severance", nil, false},
+		{"Five years of service", &taxcal.Severance{Amount: money.NewFromInt(300000), YearsOfService: 5}, false},
+		{"Zero amount", &taxcal.Severance{Amount: money.Zero, YearsOfService: 10}, true},
+		{"Negative amount", &taxcal.Severance{Amount: money.NewFromInt(-1), YearsOfService: 10}, true},
+		{"Less than five years", &taxcal.Severance{Amount: money.NewFromInt(300000), YearsOfService: 4}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSeverance(tt.severance)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateSeverance() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateSeveranceRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     SeveranceRequest
+		wantErr bool
+	}{
+		{"Valid", SeveranceRequest{Amount: money.NewFromInt(1000000), YearsOfService: 10}, false},
+		{"Supported tax year", SeveranceRequest{Amount: money.NewFromInt(1000000), YearsOfService: 10, TaxYear: 2566}, false},
+		{"Unsupported tax year", SeveranceRequest{Amount: money.NewFromInt(1000000), YearsOfService: 10, TaxYear: 2500}, true},
+		{"Missing years of service", SeveranceRequest{Amount: money.NewFromInt(1000000)}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSeveranceRequest(tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateSeveranceRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}